@@ -0,0 +1,171 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/julienschmidt/httprouter"
+)
+
+func init() {
+	endpoints = append(endpoints, &Bundles{})
+}
+
+type Bundles struct{}
+
+// ExportDesign godoc
+// @Summary      exports a smart-service design
+// @Description  returns a self-contained bundle of the design, that may be imported on another platform with POST /designs/import
+// @Tags         designs
+// @Produce      json
+// @Param        id path string true "Design ID"
+// @Success      200 {object} model.SmartServiceBundle
+// @Failure      500
+// @Failure      403
+// @Failure      401
+// @Router       /designs/{id}/export [get]
+func (this *Bundles) ExportDesign(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/designs/:id/export", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.ExportDesign(token, id)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Header().Set("Content-Disposition", "attachment; filename=\"smart-service-design-"+id+".json\"")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// ExportRelease godoc
+// @Summary      exports a smart-service release
+// @Description  returns a self-contained bundle of the release, that may be imported on another platform with POST /releases/import
+// @Tags         releases
+// @Produce      json
+// @Param        id path string true "Release ID"
+// @Success      200 {object} model.SmartServiceBundle
+// @Failure      500
+// @Failure      403
+// @Failure      401
+// @Router       /releases/{id}/export [get]
+func (this *Bundles) ExportRelease(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/releases/:id/export", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.ExportRelease(token, id)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Header().Set("Content-Disposition", "attachment; filename=\"smart-service-release-"+id+".json\"")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// importDesign godoc
+// @Summary      imports a smart-service design
+// @Description  creates a new design from a bundle created by GET /designs/{id}/export; the bundle is validated and receives new ids. characteristic ids of the exporting platform may be replaced with characteristic_id_mapping
+// @Tags         designs
+// @Accept       json
+// @Produce      json
+// @Param        message body model.SmartServiceBundleImport true "SmartServiceBundleImport"
+// @Success      200 {object} model.SmartServiceDesign
+// @Failure      500
+// @Failure      400
+// @Failure      401
+// @Router       /designs/import [post]
+func importDesign(ctrl Controller) httprouter.Handle {
+	return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		bundle := model.SmartServiceBundleImport{}
+		err = json.NewDecoder(request.Body).Decode(&bundle)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.ImportDesign(token, bundle)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	}
+}
+
+// importRelease godoc
+// @Summary      imports a smart-service release
+// @Description  creates a new design and a release of it from a bundle created by GET /releases/{id}/export; the bundle is validated and receives new ids. characteristic ids of the exporting platform may be replaced with characteristic_id_mapping
+// @Tags         releases
+// @Accept       json
+// @Produce      json
+// @Param        message body model.SmartServiceBundleImport true "SmartServiceBundleImport"
+// @Success      200 {object} model.SmartServiceRelease
+// @Failure      500
+// @Failure      400
+// @Failure      401
+// @Router       /releases/import [post]
+func importRelease(ctrl Controller) httprouter.Handle {
+	return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		bundle := model.SmartServiceBundleImport{}
+		err = json.NewDecoder(request.Body).Decode(&bundle)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.ImportRelease(token, bundle)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	}
+}
@@ -17,16 +17,24 @@
 package api
 
 import (
+	"time"
+
 	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
 	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
 )
 
 type Controller interface {
 	DesignsInterface
+	DesignRevisionsInterface
+	BundlesInterface
 	ModulesInterface
 	BulkModulesInterface
 	ReleaseInterface
 	InstancesInterface
+	InstanceMigrationsInterface
+	ReleaseReconciliationInterface
+	ReleaseApprovalInterface
+	ReleasePermissionsInterface
 	MaintenanceInterface
 	VariablesInterface
 	GetNewId() string
@@ -51,37 +59,94 @@ type DesignsInterface interface {
 	ListDesigns(token auth.Token, query model.DesignQueryOptions) ([]model.SmartServiceDesign, error, int)
 	GetDesign(token auth.Token, id string) (model.SmartServiceDesign, error, int)
 	SetDesign(token auth.Token, element model.SmartServiceDesign) (model.SmartServiceDesign, error, int)
+	SetDesignWithPrecondition(token auth.Token, element model.SmartServiceDesign, expectedRevision int64) (model.SmartServiceDesign, error, int)
 	DeleteDesign(token auth.Token, id string) (error, int)
+	LintDesign(token auth.Token, element model.SmartServiceDesign) (model.DesignLintResult, error, int)
+	CloneDesign(token auth.Token, id string) (model.SmartServiceDesign, error, int)
+	CreateDesignFromRelease(token auth.Token, releaseId string) (model.SmartServiceDesign, error, int)
+	SetDesignTemplate(token auth.Token, id string, template bool) (model.SmartServiceDesign, error, int)
+}
+
+type DesignRevisionsInterface interface {
+	ListDesignRevisions(token auth.Token, designId string, query model.DesignRevisionQueryOptions) ([]model.SmartServiceDesignRevision, error, int)
+	GetDesignRevision(token auth.Token, designId string, revision int64) (model.SmartServiceDesignRevision, error, int)
+	DiffDesignRevisions(token auth.Token, designId string, fromRevision int64, toRevision int64) (model.SmartServiceDesignDiff, error, int)
+	RestoreDesignRevision(token auth.Token, designId string, revision int64) (model.SmartServiceDesign, error, int)
+}
+
+type BundlesInterface interface {
+	ExportDesign(token auth.Token, id string) (model.SmartServiceBundle, error, int)
+	ExportRelease(token auth.Token, id string) (model.SmartServiceBundle, error, int)
+	ImportDesign(token auth.Token, bundle model.SmartServiceBundleImport) (model.SmartServiceDesign, error, int)
+	ImportRelease(token auth.Token, bundle model.SmartServiceBundleImport) (model.SmartServiceRelease, error, int)
+}
+
+type InstanceMigrationsInterface interface {
+	MigrateInstances(token auth.Token, releaseId string) (model.InstanceMigrationJob, error, int)
+	GetInstanceMigrationJob(token auth.Token, releaseId string, jobId string) (model.InstanceMigrationJob, error, int)
+	ListInstanceMigrationJobs(token auth.Token, releaseId string) ([]model.InstanceMigrationJob, error, int)
+}
+
+type ReleaseReconciliationInterface interface {
+	ReconcileReleases(dryRun bool) (model.ReleaseReconciliationReport, error, int)
+	GetLastReleaseReconciliation() (model.ReleaseReconciliationReport, error, int)
+}
+
+type ReleaseApprovalInterface interface {
+	ListPendingReleases(token auth.Token) ([]model.SmartServiceReleaseExtended, error, int)
+	ApproveRelease(token auth.Token, id string, review model.SmartServiceReleaseReview) (model.SmartServiceRelease, error, int)
+	RejectRelease(token auth.Token, id string, review model.SmartServiceReleaseReview) (model.SmartServiceRelease, error, int)
+}
+
+type ReleasePermissionsInterface interface {
+	GetReleasePermissions(token auth.Token, id string) (model.ReleasePermissions, error, int)
+	SetReleasePermissions(token auth.Token, id string, permissions model.ReleasePermissions, propagate bool) (model.ReleasePermissionsUpdateResult, error, int)
 }
 
 type ReleaseInterface interface {
 	CreateRelease(token auth.Token, element model.SmartServiceRelease) (model.SmartServiceRelease, error, int)
+	CreateReleaseAsync(token auth.Token, element model.SmartServiceRelease) (model.SmartServiceRelease, error, int)
+	GetReleaseCreation(token auth.Token, releaseId string) (model.SmartServiceReleaseCreation, error, int)
+	ListReleaseCreations(token auth.Token, query model.ReleaseCreationQueryOptions) ([]model.SmartServiceReleaseCreation, error, int)
+	PreviewRelease(token auth.Token, request model.SmartServiceReleasePreviewRequest, withParameters bool) (model.SmartServiceReleasePreview, error, int)
 	DeleteRelease(token auth.Token, id string, deletePreviousReleases bool) (error, int)
+	SetReleaseLifecycleState(token auth.Token, id string, update model.SmartServiceReleaseLifecycleStateUpdate) (model.SmartServiceRelease, error, int)
+	PromoteRelease(token auth.Token, id string) (model.SmartServiceRelease, error, int)
 	GetRelease(token auth.Token, id string) (model.SmartServiceRelease, error, int)
 	GetExtendedRelease(token auth.Token, id string) (model.SmartServiceReleaseExtended, error, int)
+	DiffReleases(token auth.Token, id string, otherId string) (model.SmartServiceReleaseDiff, error, int)
+	GetReleaseStats(token auth.Token, id string) (model.SmartServiceReleaseStats, error, int)
+	AddReleaseStats(token auth.Token, releases []model.SmartServiceRelease) (error, int)
 	ListReleases(token auth.Token, query model.ReleaseQueryOptions) ([]model.SmartServiceRelease, int64, error, int)
 	ListExtendedReleases(token auth.Token, query model.ReleaseQueryOptions) (result []model.SmartServiceReleaseExtended, total int64, err error, code int)
+	GetReleaseFacets(token auth.Token, query model.ReleaseQueryOptions) (result model.ReleaseFacets, err error, code int)
 	GetReleaseParameter(token auth.Token, id string) ([]model.SmartServiceExtendedParameter, error, int)
 	GetReleaseParameterWithoutAuthCheck(token auth.Token, id string) (result []model.SmartServiceExtendedParameter, err error, code int)
+	GetReleaseParameterSchema(token auth.Token, id string) (model.ParameterJsonSchema, error, int)
 }
 
 type InstancesInterface interface {
 	CreateInstance(token auth.Token, releaseId string, instance model.SmartServiceInstanceInit) (model.SmartServiceInstance, error, int)
+	CreateTestInstance(token auth.Token, releaseId string, instance model.SmartServiceInstanceInit, ttl time.Duration) (model.SmartServiceInstance, error, int)
+	CreateInstanceDryRun(token auth.Token, releaseId string, instance model.SmartServiceInstanceInit) (model.CamundaStartForm, error, int)
 	ListInstances(token auth.Token, query model.InstanceQueryOptions) ([]model.SmartServiceInstance, int64, error, int)
 	GetInstance(token auth.Token, id string) (model.SmartServiceInstance, error, int)
 	DeleteInstance(token auth.Token, id string, ignoreModuleDeleteError bool) (error, int)
 	SetInstanceError(token auth.Token, instanceId string, errMsg string) (error, int)
 	SetInstanceErrorByProcessInstanceId(processInstanceId string, errMsg string) (error, int)
 	UpdateInstanceInfo(token auth.Token, id string, element model.SmartServiceInstanceInfo) (model.SmartServiceInstance, error, int)
+	UpdateInstanceInfoWithPrecondition(token auth.Token, id string, element model.SmartServiceInstanceInfo, expectedUpdatedAt int64) (model.SmartServiceInstance, error, int)
 	RedeployInstance(token auth.Token, id string, parameters []model.SmartServiceParameter, releaseId string) (model.SmartServiceInstance, error, int)
 	GetInstanceUserIdByProcessInstanceId(processInstanceId string) (string, error, int)
 	GetInstanceByProcessInstanceId(processInstanceId string) (model.SmartServiceInstance, error, int)
+	GetInstanceHistory(token auth.Token, instanceId string, query model.InstanceEventQueryOptions) ([]model.SmartServiceInstanceEvent, int64, error, int)
 }
 
 type MaintenanceInterface interface {
 	GetMaintenanceProceduresOfInstance(token auth.Token, instanceId string) (maintenanceProcedure []model.MaintenanceProcedure, instance model.SmartServiceInstance, release model.SmartServiceReleaseExtended, err error, code int)
 	GetMaintenanceProcedureOfInstance(token auth.Token, instanceId string, publicEventId string) (maintenanceProcedure model.MaintenanceProcedure, instance model.SmartServiceInstance, release model.SmartServiceReleaseExtended, err error, code int)
 	GetMaintenanceProcedureParametersOfInstance(token auth.Token, instanceId string, publicEventId string) ([]model.SmartServiceExtendedParameter, error, int)
+	GetMaintenanceProcedureParameterSchemaOfInstance(token auth.Token, instanceId string, publicEventId string) (model.ParameterJsonSchema, error, int)
 	StartMaintenanceProcedure(token auth.Token, instanceId string, publicEventId string, parameters model.SmartServiceParameters) (error, int)
 }
 
@@ -0,0 +1,216 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/julienschmidt/httprouter"
+)
+
+func init() {
+	endpoints = append(endpoints, &DesignRevisions{})
+}
+
+type DesignRevisions struct{}
+
+// List godoc
+// @Summary      returns the revisions of a smart-service design
+// @Description  returns the revisions of a smart-service design; every update of a design is stored as new revision
+// @Tags         designs
+// @Param        id path string true "Design ID"
+// @Param        limit query integer false "limits size of result; 0 means unlimited"
+// @Param        offset query integer false "offset to be used in combination with limit"
+// @Param        sort query string false "describes the sorting in the form of revision.desc"
+// @Produce      json
+// @Success      200 {array} model.SmartServiceDesignRevision
+// @Failure      500
+// @Failure      404
+// @Failure      401
+// @Router       /designs/{id}/revisions [get]
+func (this *DesignRevisions) List(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/designs/:id/revisions", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		query := model.DesignRevisionQueryOptions{}
+		limit := request.URL.Query().Get("limit")
+		if limit != "" {
+			query.Limit, err = strconv.Atoi(limit)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		offset := request.URL.Query().Get("offset")
+		if offset != "" {
+			query.Offset, err = strconv.Atoi(offset)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		query.Sort = request.URL.Query().Get("sort")
+
+		result, err, code := ctrl.ListDesignRevisions(token, id, query)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// Get godoc
+// @Summary      returns a revision of a smart-service design
+// @Description  returns a revision of a smart-service design
+// @Tags         designs
+// @Produce      json
+// @Param        id path string true "Design ID"
+// @Param        rev path integer true "Revision"
+// @Success      200 {object} model.SmartServiceDesignRevision
+// @Failure      500
+// @Failure      404
+// @Failure      400
+// @Failure      401
+// @Router       /designs/{id}/revisions/{rev} [get]
+func (this *DesignRevisions) Get(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/designs/:id/revisions/:rev", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		rev, err := strconv.ParseInt(params.ByName("rev"), 10, 64)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.GetDesignRevision(token, id, rev)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// Diff godoc
+// @Summary      compares two revisions of a smart-service design
+// @Description  returns the added, removed and changed bpmn elements and start event form fields between revision rev and other_rev
+// @Tags         designs
+// @Produce      json
+// @Param        id path string true "Design ID"
+// @Param        rev path integer true "Revision used as base of the comparison"
+// @Param        other_rev path integer true "Revision compared to rev"
+// @Success      200 {object} model.SmartServiceDesignDiff
+// @Failure      500
+// @Failure      404
+// @Failure      400
+// @Failure      401
+// @Router       /designs/{id}/revisions/{rev}/diff/{other_rev} [get]
+func (this *DesignRevisions) Diff(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/designs/:id/revisions/:rev/diff/:other_rev", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		rev, err := strconv.ParseInt(params.ByName("rev"), 10, 64)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		otherRev, err := strconv.ParseInt(params.ByName("other_rev"), 10, 64)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.DiffDesignRevisions(token, id, rev, otherRev)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// Restore godoc
+// @Summary      restores a revision of a smart-service design
+// @Description  saves the content of the revision as new revision of the smart-service design
+// @Tags         designs
+// @Produce      json
+// @Param        id path string true "Design ID"
+// @Param        rev path integer true "Revision"
+// @Success      200 {object} model.SmartServiceDesign
+// @Failure      500
+// @Failure      404
+// @Failure      409
+// @Failure      400
+// @Failure      401
+// @Router       /designs/{id}/revisions/{rev}/restore [post]
+func (this *DesignRevisions) Restore(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.POST("/designs/:id/revisions/:rev/restore", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		rev, err := strconv.ParseInt(params.ByName("rev"), 10, 64)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.RestoreDesignRevision(token, id, rev)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
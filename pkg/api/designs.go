@@ -34,7 +34,7 @@ type Designs struct{}
 
 // List godoc
 // @Summary      returns a list of smart-service designs
-// @Description  returns a list of smart-service designs
+// @Description  returns a list of smart-service designs the user has read access to; templates of other users are listed by GET /design-templates
 // @Tags         designs
 // @Param        limit query integer false "limits size of result; 0 means unlimited"
 // @Param        offset query integer false "offset to be used in combination with limit"
@@ -52,6 +52,7 @@ func (this *Designs) List(config configuration.Config, router *httprouter.Router
 			http.Error(writer, err.Error(), http.StatusUnauthorized)
 			return
 		}
+		noTemplates := false
 		query := model.DesignQueryOptions{}
 		limit := request.URL.Query().Get("limit")
 		if limit != "" {
@@ -74,6 +75,7 @@ func (this *Designs) List(config configuration.Config, router *httprouter.Router
 			query.Sort = "name.asc"
 		}
 		query.Search = request.URL.Query().Get("search")
+		query.Template = &noTemplates
 
 		result, err, code := ctrl.ListDesigns(token, query)
 		if err != nil {
@@ -87,12 +89,14 @@ func (this *Designs) List(config configuration.Config, router *httprouter.Router
 
 // Get godoc
 // @Summary      returns a smart-service designs
-// @Description  returns a smart-service designs
+// @Description  returns a smart-service designs; the ETag header contains the design revision and may be used as If-Match in PUT /designs/{id}
 // @Tags         designs
 // @Produce      json
 // @Param        id path string true "Design ID"
 // @Success      200 {object} model.SmartServiceDesign
+// @Header       200 {string} ETag "design revision"
 // @Failure      500
+// @Failure      403
 // @Failure      401
 // @Router       /designs/{id} [get]
 func (this *Designs) Get(config configuration.Config, router *httprouter.Router, ctrl Controller) {
@@ -112,6 +116,7 @@ func (this *Designs) Get(config configuration.Config, router *httprouter.Router,
 			http.Error(writer, err.Error(), code)
 			return
 		}
+		setETag(writer, result.Revision)
 		writer.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(writer).Encode(result)
 	})
@@ -119,15 +124,19 @@ func (this *Designs) Get(config configuration.Config, router *httprouter.Router,
 
 // Update godoc
 // @Summary      updates a smart-service designs
-// @Description  updates a smart-service designs
+// @Description  updates a smart-service designs; if the If-Match header is set, the update is only applied if it matches the current ETag (design revision)
 // @Tags         designs
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "Design ID"
+// @Param        If-Match header string false "ETag of GET /designs/{id}"
 // @Param        message body model.SmartServiceDesign true "SmartServiceDesign"
 // @Success      200 {object} model.SmartServiceDesign
+// @Header       200 {string} ETag "design revision"
 // @Failure      500
 // @Failure      400
+// @Failure      403
+// @Failure      412
 // @Failure      401
 // @Router       /designs/{id} [put]
 func (this *Designs) Update(config configuration.Config, router *httprouter.Router, ctrl Controller) {
@@ -160,11 +169,24 @@ func (this *Designs) Update(config configuration.Config, router *httprouter.Rout
 
 		element.UserId = token.GetUserId()
 
-		result, err, code := ctrl.SetDesign(token, element)
+		expectedRevision, ifMatch, err := getIfMatch(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusPreconditionFailed)
+			return
+		}
+
+		var result model.SmartServiceDesign
+		var code int
+		if ifMatch {
+			result, err, code = ctrl.SetDesignWithPrecondition(token, element, expectedRevision)
+		} else {
+			result, err, code = ctrl.SetDesign(token, element)
+		}
 		if err != nil {
 			http.Error(writer, err.Error(), code)
 			return
 		}
+		setETag(writer, result.Revision)
 		writer.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(writer).Encode(result)
 	})
@@ -219,6 +241,7 @@ func (this *Designs) Create(config configuration.Config, router *httprouter.Rout
 // @Param        id path string true "Design ID"
 // @Success      200
 // @Failure      500
+// @Failure      403
 // @Failure      401
 // @Router       /designs/{id} [delete]
 func (this *Designs) Delete(config configuration.Config, router *httprouter.Router, ctrl Controller) {
@@ -242,3 +265,222 @@ func (this *Designs) Delete(config configuration.Config, router *httprouter.Rout
 		writer.WriteHeader(http.StatusOK)
 	})
 }
+
+// PostStatic serves POST /designs/lint and POST /designs/import.
+// httprouter does not allow static path segments next to the :id wildcard of POST /designs/:id/clone,
+// so both share the route POST /designs/:id
+func (this *Designs) PostStatic(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	lint := lintDesign(ctrl)
+	importBundle := importDesign(ctrl)
+	router.POST("/designs/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		switch params.ByName("id") {
+		case "lint":
+			lint(writer, request, params)
+		case "import":
+			importBundle(writer, request, params)
+		default:
+			http.NotFound(writer, request)
+		}
+	})
+}
+
+// lintDesign godoc
+// @Summary      lints a smart-service design
+// @Description  checks the bpmn xml of the design and returns every found problem as diagnostic; if bpmn_xml is empty, the stored design with the given id is checked
+// @Tags         designs
+// @Accept       json
+// @Produce      json
+// @Param        message body model.SmartServiceDesign true "SmartServiceDesign"
+// @Success      200 {object} model.DesignLintResult
+// @Failure      500
+// @Failure      403
+// @Failure      401
+// @Router       /designs/lint [post]
+func lintDesign(ctrl Controller) httprouter.Handle {
+	return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		element := model.SmartServiceDesign{}
+		err = json.NewDecoder(request.Body).Decode(&element)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.LintDesign(token, element)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	}
+}
+
+// ListTemplates godoc
+// @Summary      returns a list of smart-service design templates
+// @Description  returns a list of smart-service designs flagged as template; templates may be copied with POST /designs/{id}/clone
+// @Tags         designs
+// @Param        limit query integer false "limits size of result; 0 means unlimited"
+// @Param        offset query integer false "offset to be used in combination with limit"
+// @Param        sort query string false "describes the sorting in the form of name.asc"
+// @Param		 search query string false "optional text search (mongo text index behavior)"
+// @Produce      json
+// @Success      200 {array} model.SmartServiceDesign
+// @Failure      500
+// @Failure      401
+// @Router       /design-templates [get]
+func (this *Designs) ListTemplates(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/design-templates", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		onlyTemplates := true
+		query := model.DesignQueryOptions{Template: &onlyTemplates}
+		limit := request.URL.Query().Get("limit")
+		if limit != "" {
+			query.Limit, err = strconv.Atoi(limit)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		offset := request.URL.Query().Get("offset")
+		if offset != "" {
+			query.Offset, err = strconv.Atoi(offset)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		query.Sort = request.URL.Query().Get("sort")
+		if query.Sort == "" {
+			query.Sort = "name.asc"
+		}
+		query.Search = request.URL.Query().Get("search")
+
+		result, err, code := ctrl.ListDesigns(token, query)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// SetTemplate godoc
+// @Summary      flags a smart-service design as template
+// @Description  flags (true) or unflags (false) a smart-service design as template; templates are readable by every user; only admins may use this endpoint
+// @Tags         designs
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Design ID"
+// @Param        message body bool true "template flag"
+// @Success      200 {object} model.SmartServiceDesign
+// @Failure      500
+// @Failure      400
+// @Failure      403
+// @Failure      401
+// @Router       /designs/{id}/template [put]
+func (this *Designs) SetTemplate(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.PUT("/designs/:id/template", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if !token.IsAdmin() {
+			http.Error(writer, "only admins may flag design templates", http.StatusForbidden)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		template := false
+		err = json.NewDecoder(request.Body).Decode(&template)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.SetDesignTemplate(token, id, template)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// Clone godoc
+// @Summary      clones a smart-service design
+// @Description  creates a new design owned by the requesting user with the content of a readable design (e.g. a shared design or a template)
+// @Tags         designs
+// @Produce      json
+// @Param        id path string true "Design ID"
+// @Success      200 {object} model.SmartServiceDesign
+// @Failure      500
+// @Failure      403
+// @Failure      401
+// @Router       /designs/{id}/clone [post]
+func (this *Designs) Clone(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.POST("/designs/:id/clone", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.CloneDesign(token, id)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// CreateFromRelease godoc
+// @Summary      creates a smart-service design from a release
+// @Description  creates a new design owned by the requesting user with the bpmn and svg of a readable release
+// @Tags         designs, releases
+// @Produce      json
+// @Param        id path string true "Release ID"
+// @Success      200 {object} model.SmartServiceDesign
+// @Failure      500
+// @Failure      403
+// @Failure      401
+// @Router       /releases/{id}/to-design [post]
+func (this *Designs) CreateFromRelease(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.POST("/releases/:id/to-design", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.CreateDesignFromRelease(token, id)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var errInvalidIfMatch = errors.New("invalid If-Match header; expected an ETag returned by a previous request")
+
+// setETag sets a strong ETag with the given version (design revision or instance updated_at)
+func setETag(writer http.ResponseWriter, version int64) {
+	writer.Header().Set("ETag", "\""+strconv.FormatInt(version, 10)+"\"")
+}
+
+// getIfMatch returns the version of the If-Match header; set is false if the header is missing or "*"
+func getIfMatch(request *http.Request) (version int64, set bool, err error) {
+	value := strings.TrimSpace(request.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, false, nil
+	}
+	value = strings.TrimPrefix(value, "W/")
+	value = strings.Trim(value, "\"")
+	version, err = strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, true, errInvalidIfMatch
+	}
+	return version, true, nil
+}
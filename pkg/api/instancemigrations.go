@@ -0,0 +1,144 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/configuration"
+	"github.com/julienschmidt/httprouter"
+)
+
+func init() {
+	endpoints = append(endpoints, &InstanceMigrations{})
+}
+
+type InstanceMigrations struct{}
+
+// Migrate godoc
+// @Summary      migrates instances to a release
+// @Description  starts a background job, which redeploys every instance with new_release_id == id (and administrate and write rights of the user) onto the release.
+// @Description  parameters are carried over by id if they are compatible with the release; instances with missing parameters are reported with the status needs_input and have to be redeployed manually.
+// @Description  the progress of the job may be checked with GET /releases/{id}/migrate-instances/{job_id}
+// @Tags         releases, instances
+// @Produce      json
+// @Param        id path string true "Release ID"
+// @Success      202 {object} model.InstanceMigrationJob
+// @Failure      500
+// @Failure      404
+// @Failure      403
+// @Failure      400
+// @Failure      401
+// @Router       /releases/{id}/migrate-instances [post]
+func (this *InstanceMigrations) Migrate(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.POST("/releases/:id/migrate-instances", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.MigrateInstances(token, id)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// List godoc
+// @Summary      lists instance migration jobs of a release
+// @Description  lists instance migration jobs of a release, newest first; admins see the jobs of all users
+// @Tags         releases, instances
+// @Produce      json
+// @Param        id path string true "Release ID"
+// @Success      200 {array} model.InstanceMigrationJob
+// @Failure      500
+// @Failure      404
+// @Failure      403
+// @Failure      401
+// @Router       /releases/{id}/migrate-instances [get]
+func (this *InstanceMigrations) List(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/releases/:id/migrate-instances", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.ListInstanceMigrationJobs(token, id)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// Get godoc
+// @Summary      returns an instance migration job
+// @Description  returns an instance migration job with the per-instance results
+// @Tags         releases, instances
+// @Produce      json
+// @Param        id path string true "Release ID"
+// @Param        job_id path string true "Job ID"
+// @Success      200 {object} model.InstanceMigrationJob
+// @Failure      500
+// @Failure      404
+// @Failure      403
+// @Failure      401
+// @Router       /releases/{id}/migrate-instances/{job_id} [get]
+func (this *InstanceMigrations) Get(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/releases/:id/migrate-instances/:job_id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		jobId := params.ByName("job_id")
+		if jobId == "" {
+			http.Error(writer, "missing job_id", http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.GetInstanceMigrationJob(token, id, jobId)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
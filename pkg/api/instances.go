@@ -18,12 +18,14 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
 	"github.com/SENERGY-Platform/smart-service-repository/pkg/configuration"
 	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
 	"github.com/julienschmidt/httprouter"
 	"net/http"
 	"strconv"
+	"strings"
 )
 
 func init() {
@@ -40,6 +42,20 @@ type Instances struct{}
 // @Param        offset query integer false "offset to be used in combination with limit"
 // @Param        sort query string false "describes the sorting in the form of name.asc"
 // @Param        release-id query string false "only return instances from this release id"
+// @Param        state query string false "only return instances in one of these states (comma-separated; creating, starting, ready, maintenance, error, deleting, stopped)"
+// @Param        state_changed_before query integer false "only return instances whose state has not changed since this unix timestamp"
+// @Param        test query bool false "true: only return test instances (see POST /releases/{id}/test-instances); default: test instances are hidden"
+// @Param        search query string false "optional text search on name and description (mongo text index behavior)"
+// @Param        design_id query string false "only return instances of this design id"
+// @Param        ready query bool false "filter by the stored ready flag"
+// @Param        error query bool false "true: only instances with an error; false: only instances without error (module errors are not considered)"
+// @Param        deleting query bool false "filter by the deleting flag"
+// @Param        outdated query bool false "true: only instances with a newer release (new_release_id is set); false: only up-to-date instances"
+// @Param        created_after query integer false "only return instances created after this unix timestamp"
+// @Param        created_before query integer false "only return instances created before this unix timestamp"
+// @Param        updated_after query integer false "only return instances updated after this unix timestamp"
+// @Param        updated_before query integer false "only return instances updated before this unix timestamp"
+// @Param        shared query bool false "true: only instances shared by other users; false: only own instances"
 // @Produce      json
 // @Success      200 {array}  model.SmartServiceInstance
 // @Header       200 {integer}  X-Total-Count  "count of all matching elements; used for pagination"
@@ -71,6 +87,32 @@ func (this *Instances) List(config configuration.Config, router *httprouter.Rout
 			}
 		}
 		query.ReleaseId = request.URL.Query().Get("release-id")
+		state := request.URL.Query().Get("state")
+		if state != "" {
+			query.States = strings.Split(state, ",")
+		}
+		stateChangedBefore := request.URL.Query().Get("state_changed_before")
+		if stateChangedBefore != "" {
+			query.StateChangedBefore, err = strconv.ParseInt(stateChangedBefore, 10, 64)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		testStr := request.URL.Query().Get("test")
+		if testStr != "" {
+			test, err := strconv.ParseBool(testStr)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+			query.Test = &test
+		}
+		err = parseInstanceSearchQuery(request, &query)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
 		query.Sort = request.URL.Query().Get("sort")
 		if query.Sort == "" {
 			query.Sort = "name.asc"
@@ -88,11 +130,12 @@ func (this *Instances) List(config configuration.Config, router *httprouter.Rout
 
 // Get godoc
 // @Summary      returns a smart-service instance
-// @Description  returns a smart-service instance
+// @Description  returns a smart-service instance; the ETag header contains updated_at and may be used as If-Match in PUT /instances/{id}/info
 // @Tags         instances
 // @Produce      json
 // @Param        id path string true "Instance ID"
 // @Success      200 {object}  model.SmartServiceInstance
+// @Header       200 {string} ETag "instance updated_at"
 // @Failure      500
 // @Failure      401
 // @Router       /instances/{id} [get]
@@ -113,6 +156,7 @@ func (this *Instances) Get(config configuration.Config, router *httprouter.Route
 			http.Error(writer, err.Error(), code)
 			return
 		}
+		setETag(writer, result.UpdatedAt)
 		writer.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(writer).Encode(result)
 	})
@@ -291,11 +335,14 @@ func (this *Instances) SetErrorByProcessInstance(config configuration.Config, ro
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "Instance ID"
+// @Param        If-Match header string false "ETag of GET /instances/{id}; the update is only applied if it matches the current ETag"
 // @Param        message body model.SmartServiceInstanceInfo true "SmartServiceParameter"
 // @Success      200 {object}  model.SmartServiceInstance
+// @Header       200 {string} ETag "instance updated_at"
 // @Failure      500
 // @Failure      401
 // @Failure      404
+// @Failure      412
 // @Router       /instances/{id}/info [put]
 func (this *Instances) UpdateInfo(config configuration.Config, router *httprouter.Router, ctrl Controller) {
 	router.PUT("/instances/:id/info", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
@@ -318,11 +365,24 @@ func (this *Instances) UpdateInfo(config configuration.Config, router *httproute
 			return
 		}
 
-		result, err, code := ctrl.UpdateInstanceInfo(token, id, element)
+		expectedUpdatedAt, ifMatch, err := getIfMatch(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusPreconditionFailed)
+			return
+		}
+
+		var result model.SmartServiceInstance
+		var code int
+		if ifMatch {
+			result, err, code = ctrl.UpdateInstanceInfoWithPrecondition(token, id, element, expectedUpdatedAt)
+		} else {
+			result, err, code = ctrl.UpdateInstanceInfo(token, id, element)
+		}
 		if err != nil {
 			http.Error(writer, err.Error(), code)
 			return
 		}
+		setETag(writer, result.UpdatedAt)
 		writer.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(writer).Encode(result)
 	})
@@ -330,7 +390,7 @@ func (this *Instances) UpdateInfo(config configuration.Config, router *httproute
 
 // Redeploy godoc
 // @Summary      updates smart-service instance parameter
-// @Description  updates smart-service instance parameter
+// @Description  updates smart-service instance parameter; the parameters are validated against the parameter descriptions of the (new) release
 // @Tags         instances, parameter
 // @Accept       json
 // @Produce      json
@@ -339,6 +399,7 @@ func (this *Instances) UpdateInfo(config configuration.Config, router *httproute
 // @Param        message body model.SmartServiceParameters true "SmartServiceParameter"
 // @Success      200 {object}  model.SmartServiceInstance
 // @Failure      500
+// @Failure      400
 // @Failure      401
 // @Router       /instances/{id}/parameters [put]
 func (this *Instances) Redeploy(config configuration.Config, router *httprouter.Router, ctrl Controller) {
@@ -373,3 +434,97 @@ func (this *Instances) Redeploy(config configuration.Config, router *httprouter.
 		json.NewEncoder(writer).Encode(result)
 	})
 }
+
+// History godoc
+// @Summary      returns the event history of a smart-service instance
+// @Description  returns the append-only event history of a smart-service instance (creation, redeploy, info updates, errors, module and variable changes, maintenance starts and deletion) with the acting user or process instance; after the instance is deleted, the history is only readable by admins
+// @Tags         instances
+// @Produce      json
+// @Param        id path string true "Instance ID"
+// @Param        limit query integer false "limits size of result; 0 means unlimited"
+// @Param        offset query integer false "offset to be used in combination with limit"
+// @Param        sort query string false "describes the sorting in the form of time.asc"
+// @Success      200 {array}  model.SmartServiceInstanceEvent
+// @Header       200 {integer}  X-Total-Count  "count of all matching elements; used for pagination"
+// @Failure      500
+// @Failure      401
+// @Failure      403
+// @Router       /instances/{id}/history [get]
+func (this *Instances) History(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/instances/:id/history", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		query := model.InstanceEventQueryOptions{}
+		limit := request.URL.Query().Get("limit")
+		if limit != "" {
+			query.Limit, err = strconv.Atoi(limit)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		offset := request.URL.Query().Get("offset")
+		if offset != "" {
+			query.Offset, err = strconv.Atoi(offset)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		query.Sort = request.URL.Query().Get("sort")
+		result, total, err, code := ctrl.GetInstanceHistory(token, id, query)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+func parseInstanceSearchQuery(request *http.Request, query *model.InstanceQueryOptions) (err error) {
+	query.Search = request.URL.Query().Get("search")
+	query.DesignId = request.URL.Query().Get("design_id")
+	for param, target := range map[string]**bool{
+		"ready":    &query.Ready,
+		"error":    &query.HasError,
+		"deleting": &query.Deleting,
+		"outdated": &query.OutdatedRelease,
+		"shared":   &query.Shared,
+	} {
+		value := request.URL.Query().Get(param)
+		if value == "" {
+			continue
+		}
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid %v: %w", param, err)
+		}
+		*target = &b
+	}
+	for param, target := range map[string]*int64{
+		"created_after":  &query.CreatedAfter,
+		"created_before": &query.CreatedBefore,
+		"updated_after":  &query.UpdatedAfter,
+		"updated_before": &query.UpdatedBefore,
+	} {
+		value := request.URL.Query().Get(param)
+		if value == "" {
+			continue
+		}
+		*target, err = strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %v: %w", param, err)
+		}
+	}
+	return nil
+}
@@ -114,6 +114,35 @@ func (this *Maintenance) GetMaintenanceProcedureParameters(config configuration.
 	})
 }
 
+// GetMaintenanceProcedureParameterSchema godoc
+// @Summary      returns parameters of a smart-service maintenance procedure as json schema
+// @Description  returns parameters of a smart-service maintenance procedure as json schema; the described object maps parameter ids to values; options are resolved for the requesting user and listed as enum
+// @Tags         instances, maintenance-procedures, parameter
+// @Produce      json
+// @Param        id path string true "Instance ID"
+// @Param        public_event_id path string true "public event id of maintenance-procedure"
+// @Success      200 {object} model.ParameterJsonSchema
+// @Failure      500
+// @Failure      404
+// @Failure      401
+// @Router       /instances/{id}/maintenance-procedures/{public_event_id}/parameters/schema [get]
+func (this *Maintenance) GetMaintenanceProcedureParameterSchema(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/instances/:id/maintenance-procedures/:public_event_id/parameters/schema", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		result, err, code := ctrl.GetMaintenanceProcedureParameterSchemaOfInstance(token, params.ByName("id"), params.ByName("public_event_id"))
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/schema+json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
 // Start godoc
 // @Summary      start a smart-service instance maintenance procedure
 // @Description  start a smart-service instance maintenance procedure
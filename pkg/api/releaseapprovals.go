@@ -0,0 +1,148 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/julienschmidt/httprouter"
+)
+
+func init() {
+	endpoints = append(endpoints, &ReleaseApprovals{})
+}
+
+type ReleaseApprovals struct{}
+
+// listPendingReleases godoc
+// @Summary      lists releases waiting for approval
+// @Description  lists releases in the lifecycle_state pending_approval, oldest first; only users with the configured reviewer role (or admins) may use this endpoint
+// @Tags         releases, approval
+// @Produce      json
+// @Success      200 {array} model.SmartServiceReleaseExtended
+// @Failure      500
+// @Failure      403
+// @Failure      401
+// @Router       /releases/pending [get]
+func listPendingReleases(ctrl Controller) httprouter.Handle {
+	return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		result, err, code := ctrl.ListPendingReleases(token)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	}
+}
+
+// Approve godoc
+// @Summary      approves a release
+// @Description  deploys and publishes a release in the lifecycle_state pending_approval and notifies its creator; reviewers may not approve their own releases
+// @Tags         releases, approval
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Release ID"
+// @Param        message body model.SmartServiceReleaseReview false "optional comment"
+// @Success      200 {object} model.SmartServiceRelease
+// @Failure      500
+// @Failure      404
+// @Failure      403
+// @Failure      400
+// @Failure      401
+// @Router       /releases/{id}/approve [post]
+func (this *ReleaseApprovals) Approve(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.POST("/releases/:id/approve", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		review := model.SmartServiceReleaseReview{}
+		if request.ContentLength != 0 {
+			err = json.NewDecoder(request.Body).Decode(&review)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		result, err, code := ctrl.ApproveRelease(token, id, review)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// Reject godoc
+// @Summary      rejects a release
+// @Description  sets a release in the lifecycle_state pending_approval to rejected and notifies its creator; the comment is required
+// @Tags         releases, approval
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Release ID"
+// @Param        message body model.SmartServiceReleaseReview true "comment"
+// @Success      200 {object} model.SmartServiceRelease
+// @Failure      500
+// @Failure      404
+// @Failure      403
+// @Failure      400
+// @Failure      401
+// @Router       /releases/{id}/reject [post]
+func (this *ReleaseApprovals) Reject(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.POST("/releases/:id/reject", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		review := model.SmartServiceReleaseReview{}
+		err = json.NewDecoder(request.Body).Decode(&review)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.RejectRelease(token, id, review)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/julienschmidt/httprouter"
+)
+
+func init() {
+	endpoints = append(endpoints, &ReleasePermissions{})
+}
+
+type ReleasePermissions struct{}
+
+// Get godoc
+// @Summary      returns the permissions of a release
+// @Description  returns the users, groups and roles with access to the release; needs administrate rights
+// @Tags         releases, permissions
+// @Produce      json
+// @Param        id path string true "Release ID"
+// @Success      200 {object} model.ReleasePermissions
+// @Failure      500
+// @Failure      404
+// @Failure      403
+// @Failure      401
+// @Router       /releases/{id}/permissions [get]
+func (this *ReleasePermissions) Get(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/releases/:id/permissions", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.GetReleasePermissions(token, id)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// Set godoc
+// @Summary      sets the permissions of a release
+// @Description  replaces the permissions of the release; needs administrate rights. at least one user needs administrate rights; write, execute and administrate rights need read rights.
+// @Description  with propagate=true the permissions are also set for all other versions of the design, the user may administrate; other versions are listed in skipped_release_ids. versions where the update failed are listed in failed_release_ids, the requested release is always updated first
+// @Tags         releases, permissions
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Release ID"
+// @Param        propagate query bool false "set the permissions for all versions of the design"
+// @Param        message body model.ReleasePermissions true "permissions"
+// @Success      200 {object} model.ReleasePermissionsUpdateResult
+// @Failure      500
+// @Failure      404
+// @Failure      403
+// @Failure      400
+// @Failure      401
+// @Router       /releases/{id}/permissions [put]
+func (this *ReleasePermissions) Set(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.PUT("/releases/:id/permissions", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		propagate := false
+		propagateStr := request.URL.Query().Get("propagate")
+		if propagateStr != "" {
+			propagate, err = strconv.ParseBool(propagateStr)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		permissions := model.ReleasePermissions{}
+		err = json.NewDecoder(request.Body).Decode(&permissions)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.SetReleasePermissions(token, id, permissions, propagate)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
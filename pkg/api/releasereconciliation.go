@@ -0,0 +1,108 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/configuration"
+	"github.com/julienschmidt/httprouter"
+)
+
+func init() {
+	endpoints = append(endpoints, &ReleaseReconciliation{})
+}
+
+type ReleaseReconciliation struct{}
+
+// Reconcile godoc
+// @Summary      reconciles releases with camunda
+// @Description  compares the finished releases with the process definitions deployed in camunda; releases missing in camunda are redeployed and deployments without release are reported; they are only removed if release_reconciliation_remove_orphans is enabled, because camunda may be shared with other services.
+// @Description  the reconciliation is also part of the periodic cleanup. only admins may use this endpoint.
+// @Tags         releases, admin
+// @Produce      json
+// @Param        dry_run query bool false "only report the differences"
+// @Success      200 {object} model.ReleaseReconciliationReport
+// @Failure      500
+// @Failure      403
+// @Failure      400
+// @Failure      401
+// @Router       /release-reconciliation [post]
+func (this *ReleaseReconciliation) Reconcile(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.POST("/release-reconciliation", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if !token.IsAdmin() {
+			http.Error(writer, "only admins may reconcile releases", http.StatusForbidden)
+			return
+		}
+		dryRun := false
+		dryRunStr := request.URL.Query().Get("dry_run")
+		if dryRunStr != "" {
+			dryRun, err = strconv.ParseBool(dryRunStr)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		result, err, code := ctrl.ReconcileReleases(dryRun)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// Get godoc
+// @Summary      returns the last release reconciliation report
+// @Description  returns the report of the last release reconciliation, that was not a dry run. only admins may use this endpoint.
+// @Tags         releases, admin
+// @Produce      json
+// @Success      200 {object} model.ReleaseReconciliationReport
+// @Failure      500
+// @Failure      404
+// @Failure      403
+// @Failure      401
+// @Router       /release-reconciliation [get]
+func (this *ReleaseReconciliation) Get(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/release-reconciliation", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if !token.IsAdmin() {
+			http.Error(writer, "only admins may read the release reconciliation", http.StatusForbidden)
+			return
+		}
+		result, err, code := ctrl.GetLastReleaseReconciliation()
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
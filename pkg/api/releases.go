@@ -22,6 +22,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
 	"github.com/SENERGY-Platform/smart-service-repository/pkg/configuration"
@@ -37,14 +38,16 @@ type Releases struct{}
 
 // Create godoc
 // @Summary      create a smart-service release
-// @Description  creates a smart-service release
+// @Description  creates a smart-service release and responds with 200 when it is ready; with async=true the design is parsed and deployed in the background, the response has the status 202 and the progress may be checked with GET /releases/{id}/status
 // @Tags         releases
 // @Accept       json
 // @Produce      json
-// @Param        id path string true "Release ID"
 // @Param        message body model.SmartServiceRelease true "SmartServiceRelease"
+// @Param        async query bool false "create the release in the background and respond with 202"
 // @Success      200 {object} model.SmartServiceRelease
+// @Success      202 {object} model.SmartServiceRelease
 // @Failure      500
+// @Failure      400
 // @Failure      401
 // @Router       /releases [post]
 func (this *Releases) Create(config configuration.Config, router *httprouter.Router, ctrl Controller) {
@@ -62,11 +65,253 @@ func (this *Releases) Create(config configuration.Config, router *httprouter.Rou
 			return
 		}
 
+		async := false
+		asyncStr := request.URL.Query().Get("async")
+		if asyncStr != "" {
+			async, err = strconv.ParseBool(asyncStr)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
 		if element.Id == "" {
 			element.Id = ctrl.GetNewId()
 		}
 
-		result, err, code := ctrl.CreateRelease(token, element)
+		var result model.SmartServiceRelease
+		var code int
+		if async {
+			result, err, code = ctrl.CreateReleaseAsync(token, element)
+		} else {
+			result, err, code = ctrl.CreateRelease(token, element)
+		}
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(code)
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// PostStatic serves POST /releases/import.
+// httprouter does not allow static path segments next to the :id wildcard of POST /releases/:id/instances,
+// so it uses the route POST /releases/:id
+func (this *Releases) PostStatic(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	importBundle := importRelease(ctrl)
+	router.POST("/releases/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		switch params.ByName("id") {
+		case "import":
+			importBundle(writer, request, params)
+		default:
+			http.NotFound(writer, request)
+		}
+	})
+}
+
+// GetStatus godoc
+// @Summary      returns the creation status of a smart-service release
+// @Description  returns the status (pending, deploying, ready, failed) of a release creation; failed creations contain the error; visible for the creator and users with read access to the release
+// @Tags         releases
+// @Produce      json
+// @Param        id path string true "Release ID"
+// @Success      200 {object} model.SmartServiceReleaseCreation
+// @Failure      500
+// @Failure      404
+// @Failure      403
+// @Failure      401
+// @Router       /releases/{id}/status [get]
+func (this *Releases) GetStatus(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/releases/:id/status", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.GetReleaseCreation(token, id)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// ListCreations godoc
+// @Summary      lists release creations of the user
+// @Description  lists the status of release creations started by the user
+// @Tags         releases
+// @Produce      json
+// @Param        limit query integer false "limits size of result; 0 means unlimited"
+// @Param        offset query integer false "offset to be used in combination with limit"
+// @Param        sort query string false "describes the sorting in the form of created_at.desc"
+// @Param        status query string false "filter by status (pending, deploying, ready, failed)"
+// @Success      200 {array} model.SmartServiceReleaseCreation
+// @Failure      500
+// @Failure      400
+// @Failure      401
+// @Router       /release-creations [get]
+func (this *Releases) ListCreations(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/release-creations", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		query := model.ReleaseCreationQueryOptions{}
+		limit := request.URL.Query().Get("limit")
+		if limit != "" {
+			query.Limit, err = strconv.Atoi(limit)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		offset := request.URL.Query().Get("offset")
+		if offset != "" {
+			query.Offset, err = strconv.Atoi(offset)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		query.Sort = request.URL.Query().Get("sort")
+		query.Status = request.URL.Query().Get("status")
+		result, err, code := ctrl.ListReleaseCreations(token, query)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// Preview godoc
+// @Summary      preview a smart-service release
+// @Description  parses and validates a design like POST /releases would, without deploying or storing anything; uses bpmn_xml if set, otherwise the design referenced by design_id
+// @Tags         releases
+// @Accept       json
+// @Produce      json
+// @Param        message body model.SmartServiceReleasePreviewRequest true "SmartServiceReleasePreviewRequest"
+// @Param        with_parameters query bool false "adds the parameters with the options of the requesting user, like GET /releases/{id}/parameter"
+// @Success      200 {object} model.SmartServiceReleasePreview
+// @Failure      500
+// @Failure      400
+// @Failure      403
+// @Failure      401
+// @Router       /release-previews [post]
+func (this *Releases) Preview(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.POST("/release-previews", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		withParameters := false
+		withParametersStr := request.URL.Query().Get("with_parameters")
+		if withParametersStr != "" {
+			withParameters, err = strconv.ParseBool(withParametersStr)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
+		element := model.SmartServiceReleasePreviewRequest{}
+		err = json.NewDecoder(request.Body).Decode(&element)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		result, err, code := ctrl.PreviewRelease(token, element, withParameters)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// SetLifecycleState godoc
+// @Summary      sets the lifecycle state of a smart-service release
+// @Description  allowed transitions: draft -> published|pending_approval (drafts are not deployed and may not be used for instances), published -> deprecated|retired, deprecated -> published|retired, retired -> deprecated; owners of instances using the release are notified if it is deprecated or retired; requires administrate rights
+// @Tags         releases
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Release ID"
+// @Param        message body model.SmartServiceReleaseLifecycleStateUpdate true "SmartServiceReleaseLifecycleStateUpdate"
+// @Success      200 {object} model.SmartServiceRelease
+// @Failure      500
+// @Failure      400
+// @Failure      403
+// @Failure      401
+// @Router       /releases/{id}/lifecycle-state [put]
+func (this *Releases) SetLifecycleState(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.PUT("/releases/:id/lifecycle-state", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		update := model.SmartServiceReleaseLifecycleStateUpdate{}
+		err = json.NewDecoder(request.Body).Decode(&update)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.SetReleaseLifecycleState(token, id, update)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// Promote godoc
+// @Summary      promotes a smart-service release to the latest release of its design
+// @Description  makes an older release the latest release of its design again (rollback) without deleting newer releases; new_release_id of all other releases of the design and their instances is set to the promoted release; requires administrate rights
+// @Tags         releases
+// @Produce      json
+// @Param        id path string true "Release ID"
+// @Success      200 {object} model.SmartServiceRelease
+// @Failure      500
+// @Failure      404
+// @Failure      400
+// @Failure      403
+// @Failure      401
+// @Router       /releases/{id}/promote [post]
+func (this *Releases) Promote(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.POST("/releases/:id/promote", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.PromoteRelease(token, id)
 		if err != nil {
 			http.Error(writer, err.Error(), code)
 			return
@@ -132,13 +377,19 @@ func (this *Releases) Delete(config configuration.Config, router *httprouter.Rou
 // @Failure      401
 // @Router       /releases/{id} [get]
 func (this *Releases) Get(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	listPending := listPendingReleases(ctrl)
 	router.GET("/releases/:id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		id := params.ByName("id")
+		//httprouter does not allow the static path /releases/pending next to the :id wildcard
+		if id == "pending" {
+			listPending(writer, request, params)
+			return
+		}
 		token, err := auth.GetParsedToken(request)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusUnauthorized)
 			return
 		}
-		id := params.ByName("id")
 		if id == "" {
 			http.Error(writer, "missing id", http.StatusBadRequest)
 			return
@@ -153,6 +404,47 @@ func (this *Releases) Get(config configuration.Config, router *httprouter.Router
 	})
 }
 
+// Diff godoc
+// @Summary      compares two releases of the same smart-service design
+// @Description  returns the added, removed and changed parameters, maintenance procedures and analytics modules between release id and other_id; reenter_parameter_ids lists the parameters which have to be (re-)entered when an instance is redeployed from id to other_id
+// @Tags         releases
+// @Produce      json
+// @Param        id path string true "Release ID used as base of the comparison"
+// @Param        other_id path string true "Release ID compared to id"
+// @Success      200 {object} model.SmartServiceReleaseDiff
+// @Failure      500
+// @Failure      404
+// @Failure      403
+// @Failure      400
+// @Failure      401
+// @Router       /releases/{id}/diff/{other_id} [get]
+func (this *Releases) Diff(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/releases/:id/diff/:other_id", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		otherId := params.ByName("other_id")
+		if otherId == "" {
+			http.Error(writer, "missing other_id", http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.DiffReleases(token, id, otherId)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
 // List godoc
 // @Summary      returns a list of smart-service releases
 // @Description  returns a list of smart-service releases
@@ -162,8 +454,17 @@ func (this *Releases) Get(config configuration.Config, router *httprouter.Router
 // @Param        rights query string false "rights needed to see a release; bay be a combination of the following letters: 'rwxa'; default = r; release rights are set with https://github.com/SENERGY-Platform/permission-command"
 // @Param        sort query string false "describes the sorting in the form of name.asc"
 // @Param		 search query string false "optional text search (permission-search/elastic-search behavior)"
+// @Param        function_id query string false "only releases with a start parameter that needs this function"
+// @Param        aspect_id query string false "only releases with a start parameter that needs this aspect"
+// @Param        device_class_id query string false "only releases with a start parameter that needs this device-class"
+// @Param        iot_type query string false "only releases with a start parameter that allows this iot type (device, device_service_group, group, import)"
+// @Param        characteristic_id query string false "only releases with a start parameter that uses this characteristic"
+// @Param        has_analytics query bool false "only releases containing analytics flows"
+// @Param        category query string false "only releases of this category"
+// @Param        tags query string false "only releases with all of these tags (comma-separated)"
 // @Param        latest query bool false "returns only newest release of the same design"
 // @Param        add-usable-flag query bool false "add 'usable' flag to result, describing if the user hase options for all iot parameters"
+// @Param        with_stats query bool false "add instance statistics (stats field) to the releases; only instances readable by the user are counted"
 // @Produce      json
 // @Success      200 {array} model.SmartServiceRelease
 // @Header       200 {integer}  X-Total-Count  "count of all matching elements; used for pagination"
@@ -206,6 +507,12 @@ func (this *Releases) List(config configuration.Config, router *httprouter.Route
 			query.Sort = "name.asc"
 		}
 		query.Search = request.URL.Query().Get("search")
+		query.ReleaseCapabilityFilter, err = parseReleaseCapabilityFilter(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		query.ReleaseCatalogFilter = parseReleaseCatalogFilter(request)
 
 		latestStr := request.URL.Query().Get("latest")
 		if latestStr != "" {
@@ -226,6 +533,16 @@ func (this *Releases) List(config configuration.Config, router *httprouter.Route
 			}
 		}
 
+		withStats := false
+		withStatsStr := request.URL.Query().Get("with_stats")
+		if withStatsStr != "" {
+			withStats, err = strconv.ParseBool(withStatsStr)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+
 		result, total, err, code := ctrl.ListReleases(token, query)
 		if err != nil {
 			http.Error(writer, err.Error(), code)
@@ -233,6 +550,14 @@ func (this *Releases) List(config configuration.Config, router *httprouter.Route
 		}
 		writer.Header().Set("X-Total-Count", strconv.FormatInt(total, 10))
 
+		if withStats {
+			err, code = ctrl.AddReleaseStats(token, result)
+			if err != nil {
+				http.Error(writer, err.Error(), code)
+				return
+			}
+		}
+
 		if addUsableFlag {
 			withUsableFlat, err := addUsableFlagToReleases(ctrl, token, result)
 			if err != nil {
@@ -248,6 +573,98 @@ func (this *Releases) List(config configuration.Config, router *httprouter.Route
 	})
 }
 
+// Facets godoc
+// @Summary      returns release counts per category and tag
+// @Description  counts the smart-service releases matching the query per category and tag; uses the same filters as GET /releases; sorted by count
+// @Tags         releases
+// @Param        rights query string false "rights needed to see a release; bay be a combination of the following letters: 'rwxa'; default = r"
+// @Param		 search query string false "optional text search"
+// @Param        function_id query string false "only releases with a start parameter that needs this function"
+// @Param        aspect_id query string false "only releases with a start parameter that needs this aspect"
+// @Param        device_class_id query string false "only releases with a start parameter that needs this device-class"
+// @Param        iot_type query string false "only releases with a start parameter that allows this iot type (device, device_service_group, group, import)"
+// @Param        characteristic_id query string false "only releases with a start parameter that uses this characteristic"
+// @Param        has_analytics query bool false "only releases containing analytics flows"
+// @Param        category query string false "only releases of this category"
+// @Param        tags query string false "only releases with all of these tags (comma-separated)"
+// @Param        latest query bool false "counts only newest release of the same design"
+// @Produce      json
+// @Success      200 {object} model.ReleaseFacets
+// @Failure      500
+// @Failure      400
+// @Failure      401
+// @Router       /release-facets [get]
+func (this *Releases) Facets(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/release-facets", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		query := model.ReleaseQueryOptions{}
+		query.Rights = request.URL.Query().Get("rights")
+		if query.Rights == "" {
+			query.Rights = "r"
+		}
+		query.Search = request.URL.Query().Get("search")
+		query.ReleaseCapabilityFilter, err = parseReleaseCapabilityFilter(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		query.ReleaseCatalogFilter = parseReleaseCatalogFilter(request)
+		latestStr := request.URL.Query().Get("latest")
+		if latestStr != "" {
+			query.Latest, err = strconv.ParseBool(latestStr)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		result, err, code := ctrl.GetReleaseFacets(token, query)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
+// Stats godoc
+// @Summary      returns usage statistics of a smart-service release
+// @Description  counts the instances (total, ready, with error, with available update) of the release and of older releases of the same design; only instances readable by the user are counted
+// @Tags         releases
+// @Produce      json
+// @Param        id path string true "Release ID"
+// @Success      200 {object} model.SmartServiceReleaseStats
+// @Failure      500
+// @Failure      404
+// @Failure      403
+// @Failure      401
+// @Router       /releases/{id}/stats [get]
+func (this *Releases) Stats(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/releases/:id/stats", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.GetReleaseStats(token, id)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
 // GetExtended godoc
 // @Summary      returns a smart-service release
 // @Description  returns a smart-service release
@@ -289,6 +706,14 @@ func (this *Releases) GetExtended(config configuration.Config, router *httproute
 // @Param        rights query string false "rights needed to see a release; bay be a combination of the following letters: 'rwxa'; default = r; release rights are set with https://github.com/SENERGY-Platform/permission-command"
 // @Param        sort query string false "describes the sorting in the form of name.asc"
 // @Param		 search query string false "optional text search (permission-search/elastic-search behavior)"
+// @Param        function_id query string false "only releases with a start parameter that needs this function"
+// @Param        aspect_id query string false "only releases with a start parameter that needs this aspect"
+// @Param        device_class_id query string false "only releases with a start parameter that needs this device-class"
+// @Param        iot_type query string false "only releases with a start parameter that allows this iot type (device, device_service_group, group, import)"
+// @Param        characteristic_id query string false "only releases with a start parameter that uses this characteristic"
+// @Param        has_analytics query bool false "only releases containing analytics flows"
+// @Param        category query string false "only releases of this category"
+// @Param        tags query string false "only releases with all of these tags (comma-separated)"
 // @Param        latest query bool false "returns only newest release of the same design"
 // @Param        add-usable-flag query bool false "add 'usable' flag to result, describing if the user hase options for all iot parameters"
 // @Param        ids query string false "limit response to ids (comma-separated)"
@@ -334,6 +759,12 @@ func (this *Releases) ListExtended(config configuration.Config, router *httprout
 			query.Sort = "name.asc"
 		}
 		query.Search = request.URL.Query().Get("search")
+		query.ReleaseCapabilityFilter, err = parseReleaseCapabilityFilter(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		query.ReleaseCatalogFilter = parseReleaseCatalogFilter(request)
 
 		latestStr := request.URL.Query().Get("latest")
 		if latestStr != "" {
@@ -382,6 +813,36 @@ func (this *Releases) ListExtended(config configuration.Config, router *httprout
 	})
 }
 
+// parseReleaseCapabilityFilter reads the filters on the parsed release info from the query parameters.
+// function_id, aspect_id, device_class_id and iot_type have to match the same start parameter.
+func parseReleaseCapabilityFilter(request *http.Request) (result model.ReleaseCapabilityFilter, err error) {
+	query := request.URL.Query()
+	result.FunctionId = query.Get("function_id")
+	result.AspectId = query.Get("aspect_id")
+	result.DeviceClassId = query.Get("device_class_id")
+	result.IotType = query.Get("iot_type")
+	result.CharacteristicId = query.Get("characteristic_id")
+	hasAnalyticsStr := query.Get("has_analytics")
+	if hasAnalyticsStr != "" {
+		result.HasAnalytics, err = strconv.ParseBool(hasAnalyticsStr)
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// parseReleaseCatalogFilter reads the category and the comma-separated tags from the query parameters.
+func parseReleaseCatalogFilter(request *http.Request) (result model.ReleaseCatalogFilter) {
+	query := request.URL.Query()
+	result.Category = query.Get("category")
+	tags := query.Get("tags")
+	if tags != "" {
+		result.Tags = strings.Split(tags, ",")
+	}
+	return result
+}
+
 func addUsableFlagToExtendedReleases(ctrl Controller, token auth.Token, releases []model.SmartServiceReleaseExtended) (result []model.SmartServiceReleaseExtendedWithUsableFlag, err error) {
 	wg := sync.WaitGroup{}
 	mux := sync.Mutex{}
@@ -476,16 +937,53 @@ func (this *Releases) Parameters(config configuration.Config, router *httprouter
 	})
 }
 
+// ParameterSchema godoc
+// @Summary      returns parameters of a release as json schema
+// @Description  returns parameters of a release as json schema; the described object maps parameter ids to values; options are resolved for the requesting user and listed as enum
+// @Tags         releases, parameter
+// @Produce      json
+// @Param        id path string true "Release ID"
+// @Success      200 {object} model.ParameterJsonSchema
+// @Failure      500
+// @Failure      404
+// @Failure      403
+// @Failure      401
+// @Router       /releases/{id}/parameters/schema [get]
+func (this *Releases) ParameterSchema(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.GET("/releases/:id/parameters/schema", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing id", http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.GetReleaseParameterSchema(token, id)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/schema+json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
+
 // Start godoc
 // @Summary      creates a smart-service instance from the release
-// @Description  creates a smart-service instance from the release
+// @Description  creates a smart-service instance from the release; the parameters are validated against the parameter descriptions of the release (unknown ids, missing required parameters, types, options and same-entity constraints); with dry_run=true, nothing is persisted or started and the camunda start form (model.CamundaStartForm) is returned instead of the instance; it contains the auto_select_all parameters and {{.id}} references for long parameter values
 // @Tags         releases, instances
 // @Accept       json
 // @Produce      json
 // @Param        id path string true "Release ID"
+// @Param        dry_run query bool false "only validate and return the camunda start form"
 // @Param        message body model.SmartServiceInstanceInit true "SmartServiceInstanceInit"
 // @Success      200 {object} model.SmartServiceInstance
 // @Failure      500
+// @Failure      400
 // @Failure      401
 // @Router       /releases/{id}/instances [post]
 func (this *Releases) Start(config configuration.Config, router *httprouter.Router, ctrl Controller) {
@@ -500,12 +998,31 @@ func (this *Releases) Start(config configuration.Config, router *httprouter.Rout
 			http.Error(writer, "missing release id", http.StatusBadRequest)
 			return
 		}
+		dryRun := false
+		dryRunStr := request.URL.Query().Get("dry_run")
+		if dryRunStr != "" {
+			dryRun, err = strconv.ParseBool(dryRunStr)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
 		instance := model.SmartServiceInstanceInit{}
 		err = json.NewDecoder(request.Body).Decode(&instance)
 		if err != nil {
 			http.Error(writer, err.Error(), http.StatusBadRequest)
 			return
 		}
+		if dryRun {
+			form, err, code := ctrl.CreateInstanceDryRun(token, id, instance)
+			if err != nil {
+				http.Error(writer, err.Error(), code)
+				return
+			}
+			writer.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(writer).Encode(form)
+			return
+		}
 		result, err, code := ctrl.CreateInstance(token, id, instance)
 		if err != nil {
 			http.Error(writer, err.Error(), code)
@@ -515,3 +1032,55 @@ func (this *Releases) Start(config configuration.Config, router *httprouter.Rout
 		json.NewEncoder(writer).Encode(result)
 	})
 }
+
+// StartTest godoc
+// @Summary      creates a test smart-service instance from the release
+// @Description  creates a smart-service instance marked as test; test instances are hidden in GET /instances (unless test=true is used) and removed with all modules and variables by the cleanup after the ttl
+// @Tags         releases, instances
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Release ID"
+// @Param        ttl query string false "time-to-live of the instance as duration (e.g. 30m); defaults to and may not exceed the configured test_instance_ttl"
+// @Param        message body model.SmartServiceInstanceInit true "SmartServiceInstanceInit"
+// @Success      200 {object} model.SmartServiceInstance
+// @Failure      500
+// @Failure      501
+// @Failure      400
+// @Failure      401
+// @Router       /releases/{id}/test-instances [post]
+func (this *Releases) StartTest(config configuration.Config, router *httprouter.Router, ctrl Controller) {
+	router.POST("/releases/:id/test-instances", func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		token, err := auth.GetParsedToken(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := params.ByName("id")
+		if id == "" {
+			http.Error(writer, "missing release id", http.StatusBadRequest)
+			return
+		}
+		var ttl time.Duration
+		ttlStr := request.URL.Query().Get("ttl")
+		if ttlStr != "" {
+			ttl, err = time.ParseDuration(ttlStr)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		instance := model.SmartServiceInstanceInit{}
+		err = json.NewDecoder(request.Body).Decode(&instance)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err, code := ctrl.CreateTestInstance(token, id, instance, ttl)
+		if err != nil {
+			http.Error(writer, err.Error(), code)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(writer).Encode(result)
+	})
+}
@@ -24,6 +24,8 @@ import (
 	"net/http"
 	"net/url"
 	"runtime/debug"
+	"strconv"
+	"strings"
 )
 
 func (this *Camunda) getProcessDefinition(id string) (result ProcessDefinition, exists bool, err error) {
@@ -56,8 +58,29 @@ func (this *Camunda) getProcessDefinition(id string) (result ProcessDefinition,
 	return
 }
 
+const processDefinitionPageSize = 500
+
+// getProcessDefinitionList reads all process definitions page by page
 func (this *Camunda) getProcessDefinitionList() (result []ProcessDefinition, err error) {
-	req, err := http.NewRequest("GET", this.config.CamundaUrl+"/engine-rest/process-definition", nil)
+	for firstResult := 0; ; firstResult += processDefinitionPageSize {
+		page, err := this.getProcessDefinitionPage(firstResult, processDefinitionPageSize)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, page...)
+		if len(page) < processDefinitionPageSize {
+			return result, nil
+		}
+	}
+}
+
+func (this *Camunda) getProcessDefinitionPage(firstResult int, maxResults int) (result []ProcessDefinition, err error) {
+	query := url.Values{}
+	query.Set("sortBy", "id")
+	query.Set("sortOrder", "asc")
+	query.Set("firstResult", strconv.Itoa(firstResult))
+	query.Set("maxResults", strconv.Itoa(maxResults))
+	req, err := http.NewRequest("GET", this.config.CamundaUrl+"/engine-rest/process-definition?"+query.Encode(), nil)
 	if err != nil {
 		return result, this.filterUrlFromErr(err)
 	}
@@ -95,3 +118,31 @@ func (this *Camunda) getProcessDefinitionListByKey(key string) (result []Process
 	err = json.NewDecoder(resp.Body).Decode(&result)
 	return
 }
+
+// IsReleaseDeployed checks if a process definition for the release exists
+func (this *Camunda) IsReleaseDeployed(id string) (bool, error) {
+	_, exists, err := this.getProcessDefinition(id)
+	return exists, err
+}
+
+// GetDeployedReleaseKeys lists the process definition keys of all deployed releases
+func (this *Camunda) GetDeployedReleaseKeys() (keys []string, err error) {
+	definitions, err := this.getProcessDefinitionList()
+	if err != nil {
+		return keys, err
+	}
+	known := map[string]bool{}
+	for _, definition := range definitions {
+		if !strings.HasPrefix(definition.Key, "id_") || known[definition.Key] {
+			continue
+		}
+		known[definition.Key] = true
+		keys = append(keys, definition.Key)
+	}
+	return keys, nil
+}
+
+// ReleaseIdToKey returns the process definition key used for the release
+func (this *Camunda) ReleaseIdToKey(id string) string {
+	return idToCNName(id)
+}
@@ -33,11 +33,7 @@ import (
 func (this *Camunda) Start(instance model.SmartServiceInstance) error {
 	requestBody := new(bytes.Buffer)
 	key := idToCNName(instance.ReleaseId)
-	variables, err := this.GetProcessParameters(key)
-	if err != nil {
-		return err
-	}
-	query, err := createCamundaStartForm(instance, variables)
+	query, err := this.GetStartForm(instance)
 	if err != nil {
 		return err
 	}
@@ -68,6 +64,15 @@ func (this *Camunda) Start(instance model.SmartServiceInstance) error {
 	return nil
 }
 
+// GetStartForm returns the form which is submitted to camunda by Start
+func (this *Camunda) GetStartForm(instance model.SmartServiceInstance) (model.CamundaStartForm, error) {
+	variables, err := this.GetProcessParameters(idToCNName(instance.ReleaseId))
+	if err != nil {
+		return model.CamundaStartForm{}, err
+	}
+	return createCamundaStartForm(instance, variables)
+}
+
 func (this *Camunda) StartMaintenance(releaseId string, procedure model.MaintenanceProcedure, id string, parameter []model.SmartServiceParameter) error {
 	requestBody := new(bytes.Buffer)
 	key := idToCNName(releaseId)
@@ -131,9 +136,9 @@ func (this *Camunda) GetProcessParameters(processDefinitionKey string) (result m
 	return
 }
 
-func createCamundaStartForm(instance model.SmartServiceInstance, variables map[string]Variable) (result CamundaStartForm, err error) {
+func createCamundaStartForm(instance model.SmartServiceInstance, variables map[string]Variable) (result model.CamundaStartForm, err error) {
 	result.BusinessKey = instance.Id
-	result.Variables = map[string]CamundaStartVariable{
+	result.Variables = map[string]model.CamundaStartVariable{
 		//model.CamundaUserIdParameter: {Value: instance.UserId},
 	}
 	for _, param := range instance.Parameters {
@@ -141,7 +146,7 @@ func createCamundaStartForm(instance model.SmartServiceInstance, variables map[s
 		if err != nil {
 			return result, err
 		}
-		result.Variables[param.Id] = CamundaStartVariable{Value: value}
+		result.Variables[param.Id] = model.CamundaStartVariable{Value: value}
 	}
 	return result, nil
 }
@@ -149,13 +154,13 @@ func createCamundaStartForm(instance model.SmartServiceInstance, variables map[s
 func createMaintenanceStartForm(procedure model.MaintenanceProcedure, id string, parameter []model.SmartServiceParameter, variables map[string]Variable) (result CamundaMaintenanceStartForm, err error) {
 	result.BusinessKey = id
 	result.MessageName = procedure.InternalEventId
-	result.ProcessVariables = map[string]CamundaStartVariable{}
+	result.ProcessVariables = map[string]model.CamundaStartVariable{}
 	for _, param := range parameter {
 		value, err := handleObjectsAsJson(param, variables)
 		if err != nil {
 			return result, err
 		}
-		result.ProcessVariables[param.Id] = CamundaStartVariable{Value: value}
+		result.ProcessVariables[param.Id] = model.CamundaStartVariable{Value: value}
 	}
 	return result, nil
 }
@@ -176,17 +181,8 @@ func handleObjectsAsJson(param model.SmartServiceParameter, variables map[string
 	return param.Value, nil
 }
 
-type CamundaStartForm struct {
-	BusinessKey string                          `json:"businessKey"`
-	Variables   map[string]CamundaStartVariable `json:"variables"`
-}
-
 type CamundaMaintenanceStartForm struct {
-	MessageName      string                          `json:"messageName"`
-	BusinessKey      string                          `json:"businessKey"`
-	ProcessVariables map[string]CamundaStartVariable `json:"processVariables"`
-}
-
-type CamundaStartVariable struct {
-	Value interface{} `json:"value"`
+	MessageName      string                                `json:"messageName"`
+	BusinessKey      string                                `json:"businessKey"`
+	ProcessVariables map[string]model.CamundaStartVariable `json:"processVariables"`
 }
@@ -39,6 +39,11 @@ type Config struct {
 	DeviceRepositoryUrl                  string   `json:"device_repository_url"`
 	SmartServiceReleasePermissionsTopic  string   `json:"smart_service_release_permissions_topic"`
 	SmartServiceInstancePermissionsTopic string   `json:"smart_service_instance_permissions_topic"`
+	SmartServiceDesignPermissionsTopic   string   `json:"smart_service_design_permissions_topic"`
+	DesignTemplateRole                   string   `json:"design_template_role"`
+	ReleasePublisherRole                 string   `json:"release_publisher_role"` //if set, releases of users without this role need the approval of a reviewer
+	ReleaseReviewerRole                  string   `json:"release_reviewer_role"`
+	ReleaseReviewerNotificationUserIds   []string `json:"release_reviewer_notification_user_ids"` //notified if a release is waiting for approval
 	NotificationUrl                      string   `json:"notification_url"`
 	MongoUrl                             string   `json:"mongo_url"`
 	MongoWithTransactions                bool     `json:"mongo_with_transactions"`
@@ -48,6 +53,10 @@ type Config struct {
 	MongoCollectionInstance              string   `json:"mongo_collection_instance"`
 	MongoCollectionModule                string   `json:"mongo_collection_module"`
 	MongoCollectionVariables             string   `json:"mongo_collection_variables"`
+	MongoCollectionDesignRevisions       string   `json:"mongo_collection_design_revisions"`
+	MongoCollectionInstanceMigrations    string   `json:"mongo_collection_instance_migrations"`
+	MongoCollectionReleaseCreations      string   `json:"mongo_collection_release_creations"`
+	MongoCollectionInstanceHistory       string   `json:"mongo_collection_instance_history"`
 	AuthEndpoint                         string   `json:"auth_endpoint"`
 	AuthClientId                         string   `json:"auth_client_id" config:"secret"`
 	AuthClientSecret                     string   `json:"auth_client_secret" config:"secret"`
@@ -55,9 +64,11 @@ type Config struct {
 	TokenCacheDefaultExpirationInSeconds int      `json:"token_cache_default_expiration_in_seconds"`
 	CleanupCycle                         string   `json:"cleanup_cycle"`
 	MarkAgeLimit                         Duration `json:"mark_age_limit"`
+	TestInstanceTtl                      Duration `json:"test_instance_ttl"` //default and max time-to-live of test instances
 	LogLevel                             string   `json:"log_level"`
 
-	DeleteUnusedOldVersionReleases bool `json:"delete_unused_old_version_releases"`
+	DeleteUnusedOldVersionReleases     bool `json:"delete_unused_old_version_releases"`
+	ReleaseReconciliationRemoveOrphans bool `json:"release_reconciliation_remove_orphans"` //if false, camunda deployments without release are only reported
 
 	logger *slog.Logger `json:"-"`
 }
@@ -27,7 +27,25 @@ import (
 )
 
 func (this *Controller) AddModules(token auth.Token, instanceId string, modules []model.SmartServiceModuleInit) (result []model.SmartServiceModule, err error, code int) {
-	return this.addModules(token.GetUserId(), instanceId, modules)
+	result, err, code = this.addModules(token.GetUserId(), instanceId, modules)
+	if err != nil {
+		return result, err, code
+	}
+	this.addModulesEvent(instanceId, userActor(token), result)
+	return result, nil, code
+}
+
+func (this *Controller) addModulesEvent(instanceId string, actor model.InstanceEventActor, modules []model.SmartServiceModule) {
+	moduleIds := []string{}
+	for _, module := range modules {
+		moduleIds = append(moduleIds, module.Id)
+	}
+	this.addInstanceEvent(model.SmartServiceInstanceEvent{
+		InstanceId: instanceId,
+		Type:       model.InstanceEventModulesAdded,
+		Actor:      actor,
+		ModuleIds:  moduleIds,
+	})
 }
 
 func (this *Controller) addModules(userId string, instanceId string, modules []model.SmartServiceModuleInit) (result []model.SmartServiceModule, err error, code int) {
@@ -63,7 +81,12 @@ func (this *Controller) AddModulesForProcessInstance(processInstanceId string, m
 	if err != nil {
 		return result, err, code
 	}
-	return this.addModules(userId, businessKey, modules)
+	result, err, code = this.addModules(userId, businessKey, modules)
+	if err != nil {
+		return result, err, code
+	}
+	this.addModulesEvent(businessKey, processInstanceActor(processInstanceId), result)
+	return result, nil, code
 }
 
 func (this *Controller) prepareModules(userId string, instanceId string, modules []model.SmartServiceModuleInit) (result []model.SmartServiceModule, err error, code int) {
@@ -0,0 +1,265 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/beevik/etree"
+)
+
+func (this *Controller) ExportDesign(token auth.Token, id string) (result model.SmartServiceBundle, err error, code int) {
+	design, err, code := this.GetDesign(token, id)
+	if err != nil {
+		return result, err, code
+	}
+	result = model.SmartServiceBundle{
+		Version:     model.SmartServiceBundleVersion,
+		Kind:        model.SmartServiceBundleKindDesign,
+		SourceId:    design.Id,
+		Name:        design.Name,
+		Description: design.Description,
+		BpmnXml:     design.BpmnXml,
+		SvgXml:      design.SvgXml,
+		ExportedAt:  time.Now().Unix(),
+	}
+	parsedInfo, err := this.parseDesignXmlForReleaseInfo(token, design.BpmnXml, model.SmartServiceRelease{Id: design.Id})
+	if err != nil {
+		this.config.GetLogger().Debug("export design without parsed info", "designId", design.Id, "error", err)
+	} else {
+		result.ParsedInfo = &parsedInfo
+	}
+	result.CharacteristicIds, err = getReferencedCharacteristicIds(design.BpmnXml)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) ExportRelease(token auth.Token, id string) (result model.SmartServiceBundle, err error, code int) {
+	release, err, code := this.GetExtendedRelease(token, id)
+	if err != nil {
+		return result, err, code
+	}
+	result = model.SmartServiceBundle{
+		Version:     model.SmartServiceBundleVersion,
+		Kind:        model.SmartServiceBundleKindRelease,
+		SourceId:    release.Id,
+		Name:        release.Name,
+		Description: release.Description,
+		BpmnXml:     release.BpmnXml,
+		SvgXml:      release.SvgXml,
+		ParsedInfo:  &release.ParsedInfo,
+		ExportedAt:  time.Now().Unix(),
+	}
+	result.CharacteristicIds, err = getReferencedCharacteristicIds(release.BpmnXml)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return result, nil, http.StatusOK
+}
+
+// ImportDesign creates a new design of the requesting user from a design bundle
+func (this *Controller) ImportDesign(token auth.Token, bundle model.SmartServiceBundleImport) (result model.SmartServiceDesign, err error, code int) {
+	if bundle.Kind != model.SmartServiceBundleKindDesign {
+		return result, fmt.Errorf("expected bundle of kind %v, got %v", model.SmartServiceBundleKindDesign, bundle.Kind), http.StatusBadRequest
+	}
+	return this.importBundleAsDesign(token, bundle)
+}
+
+// ImportRelease creates a new design and a release of this design from a release bundle
+func (this *Controller) ImportRelease(token auth.Token, bundle model.SmartServiceBundleImport) (result model.SmartServiceRelease, err error, code int) {
+	if bundle.Kind != model.SmartServiceBundleKindRelease {
+		return result, fmt.Errorf("expected bundle of kind %v, got %v", model.SmartServiceBundleKindRelease, bundle.Kind), http.StatusBadRequest
+	}
+	design, err, code := this.importBundleAsDesign(token, bundle)
+	if err != nil {
+		return result, err, code
+	}
+	result, err, code = this.CreateRelease(token, model.SmartServiceRelease{
+		DesignId:    design.Id,
+		Name:        bundle.Name,
+		Description: bundle.Description,
+	})
+	if err != nil {
+		temperr, _ := this.DeleteDesign(token, design.Id)
+		if temperr != nil {
+			this.config.GetLogger().Warn("unable to remove design of failed release import", "designId", design.Id, "error", temperr)
+		}
+		return result, err, code
+	}
+	return result, nil, code
+}
+
+func (this *Controller) importBundleAsDesign(token auth.Token, request model.SmartServiceBundleImport) (result model.SmartServiceDesign, err error, code int) {
+	bundle, err := RemapBundleCharacteristicIds(request.SmartServiceBundle, request.CharacteristicIdMapping)
+	if err != nil {
+		return result, fmt.Errorf("unable to remap characteristic ids: %w", err), http.StatusBadRequest
+	}
+	err, code = this.validateBundle(token, bundle)
+	if err != nil {
+		return result, err, code
+	}
+	return this.SetDesign(token, model.SmartServiceDesign{
+		Id:          this.GetNewId(),
+		UserId:      token.GetUserId(),
+		Name:        bundle.Name,
+		Description: bundle.Description,
+		BpmnXml:     bundle.BpmnXml,
+		SvgXml:      bundle.SvgXml,
+	})
+}
+
+func (this *Controller) validateBundle(token auth.Token, bundle model.SmartServiceBundle) (err error, code int) {
+	if bundle.Version < 1 || bundle.Version > model.SmartServiceBundleVersion {
+		return fmt.Errorf("unsupported bundle version %v", bundle.Version), http.StatusBadRequest
+	}
+	if bundle.BpmnXml == "" {
+		return errors.New("missing bpmn xml"), http.StatusBadRequest
+	}
+	if bundle.SvgXml == "" {
+		return errors.New("missing svg xml"), http.StatusBadRequest
+	}
+	characteristicIds, err := getReferencedCharacteristicIds(bundle.BpmnXml)
+	if err != nil {
+		return fmt.Errorf("invalid bpmn xml: %w", err), http.StatusBadRequest
+	}
+	for _, id := range bundle.CharacteristicIds {
+		if !slices.Contains(characteristicIds, id) {
+			characteristicIds = append(characteristicIds, id)
+		}
+	}
+	unknown := []string{}
+	for _, id := range characteristicIds {
+		_, err = this.GetCharacteristic(id)
+		if err != nil {
+			unknown = append(unknown, id)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("bundle references characteristics unknown to this platform: %v", unknown), http.StatusBadRequest
+	}
+	parsedInfo, err, code := this.parseAndValidateReleaseInfo(token, bundle.BpmnXml, model.SmartServiceRelease{})
+	if err != nil {
+		return err, code
+	}
+	if bundle.ParsedInfo != nil {
+		if !isEmptyReleaseInfoDiff(DiffReleaseInfo(*bundle.ParsedInfo, parsedInfo)) {
+			return errors.New("parsed_info of bundle does not match its bpmn xml"), http.StatusBadRequest
+		}
+	}
+	return nil, http.StatusOK
+}
+
+func isEmptyReleaseInfoDiff(diff model.SmartServiceReleaseDiff) bool {
+	return len(diff.Parameters.Added) == 0 && len(diff.Parameters.Removed) == 0 && len(diff.Parameters.Changed) == 0 &&
+		len(diff.MaintenanceProcedures.Added) == 0 && len(diff.MaintenanceProcedures.Removed) == 0 && len(diff.MaintenanceProcedures.Changed) == 0 &&
+		len(diff.Analytics.Added) == 0 && len(diff.Analytics.Removed) == 0 && len(diff.Analytics.Changed) == 0
+}
+
+// RemapBundleCharacteristicIds replaces the characteristic ids in the bpmn xml, the characteristic_ids list
+// and the parsed_info of the bundle with the mapped ids; ids without mapping are kept
+func RemapBundleCharacteristicIds(bundle model.SmartServiceBundle, mapping map[string]string) (result model.SmartServiceBundle, err error) {
+	defer func() {
+		if r := recover(); r != nil && err == nil {
+			err = errors.New(fmt.Sprint("Recovered Error: ", r, string(debug.Stack())))
+		}
+	}()
+	if len(mapping) == 0 {
+		return bundle, nil
+	}
+	result = bundle
+	remap := func(id string) string {
+		if mapped, ok := mapping[id]; ok {
+			return mapped
+		}
+		return id
+	}
+	doc := etree.NewDocument()
+	err = doc.ReadFromString(bundle.BpmnXml)
+	if err != nil {
+		return result, err
+	}
+	changed := false
+	for _, property := range doc.FindElements("//camunda:property[@id='characteristic_id']") {
+		attr := property.SelectAttr("value")
+		if attr != nil && remap(attr.Value) != attr.Value {
+			attr.Value = remap(attr.Value)
+			changed = true
+		}
+	}
+	if changed {
+		result.BpmnXml, err = doc.WriteToString()
+		if err != nil {
+			return result, err
+		}
+	}
+	result.CharacteristicIds = []string{}
+	for _, id := range bundle.CharacteristicIds {
+		result.CharacteristicIds = append(result.CharacteristicIds, remap(id))
+	}
+	if bundle.ParsedInfo != nil {
+		info := *bundle.ParsedInfo
+		info.ParameterDescriptions = remapParameterCharacteristicIds(info.ParameterDescriptions, remap)
+		info.MaintenanceProcedures = slices.Clone(info.MaintenanceProcedures)
+		for i, procedure := range info.MaintenanceProcedures {
+			info.MaintenanceProcedures[i].ParameterDescriptions = remapParameterCharacteristicIds(procedure.ParameterDescriptions, remap)
+		}
+		result.ParsedInfo = &info
+	}
+	return result, nil
+}
+
+func remapParameterCharacteristicIds(parameter []model.ParameterDescription, remap func(id string) string) (result []model.ParameterDescription) {
+	result = slices.Clone(parameter)
+	for i, param := range result {
+		if param.CharacteristicId != nil {
+			id := remap(*param.CharacteristicId)
+			result[i].CharacteristicId = &id
+		}
+	}
+	return result
+}
+
+func getReferencedCharacteristicIds(xml string) (result []string, err error) {
+	defer func() {
+		if r := recover(); r != nil && err == nil {
+			err = errors.New(fmt.Sprint("Recovered Error: ", r, string(debug.Stack())))
+		}
+	}()
+	doc := etree.NewDocument()
+	err = doc.ReadFromString(xml)
+	if err != nil {
+		return result, err
+	}
+	result = []string{}
+	for _, property := range doc.FindElements("//camunda:property[@id='characteristic_id']") {
+		id := property.SelectAttrValue("value", "")
+		if id != "" && !slices.Contains(result, id) {
+			result = append(result, id)
+		}
+	}
+	slices.Sort(result)
+	return result, nil
+}
@@ -17,8 +17,11 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
+	"time"
 
+	"github.com/SENERGY-Platform/permissions-v2/pkg/client"
 	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
 )
 
@@ -27,7 +30,16 @@ func (this *Controller) Cleanup(ignoreModuleDeleteError bool) (result []error) {
 	this.cleanupMux.Lock()
 	defer this.cleanupMux.Unlock()
 	this.retryMarkedReleases()
-	err := this.instanceCleanup()
+	reconciliation := this.releaseReconciliation(false)
+	this.config.GetLogger().Info("release reconciliation", "redeployed", reconciliation.Redeployed, "orphanedDeployments", reconciliation.OrphanedDeployments, "removedDeployments", reconciliation.RemovedDeployments, "errors", reconciliation.Errors)
+	for _, e := range reconciliation.Errors {
+		result = append(result, errors.New(e))
+	}
+	err := this.testInstanceCleanup(ignoreModuleDeleteError)
+	if err != nil {
+		result = append(result, err...)
+	}
+	err = this.instanceCleanup()
 	if err != nil {
 		result = append(result, err...)
 	}
@@ -42,6 +54,35 @@ func (this *Controller) Cleanup(ignoreModuleDeleteError bool) (result []error) {
 	return result
 }
 
+// testInstanceCleanup removes expired test instances with their modules, variables, history and permissions
+func (this *Controller) testInstanceCleanup(ignoreModuleDeleteError bool) (result []error) {
+	instances, err, _ := this.db.ListExpiredTestInstances(time.Now().Unix())
+	if err != nil {
+		return []error{err}
+	}
+	for _, instance := range instances {
+		this.config.GetLogger().Info("found expired test instance --> remove", "instanceId", instance.Id, "expiresAt", instance.ExpiresAt)
+		err = this.camunda.StopInstance(instance.Id)
+		if err == nil {
+			err, _ = this.handleModuleDeleteReferencesOfInstance(instance.Id, ignoreModuleDeleteError)
+		}
+		if err == nil {
+			err, _ = this.db.DeleteInstance(instance.Id, "")
+		}
+		if err == nil {
+			err, _ = this.db.DeleteInstanceEvents(instance.Id)
+		}
+		if err == nil {
+			err, _ = this.permissions.RemoveResource(client.InternalAdminToken, this.config.SmartServiceInstancePermissionsTopic, instance.Id)
+		}
+		if err != nil {
+			result = append(result, err)
+			this.config.GetLogger().Error("unable to remove expired test instance", "instanceId", instance.Id, "error", err)
+		}
+	}
+	return result
+}
+
 func (this *Controller) instanceCleanup() (result []error) {
 	instances, err := this.camunda.GetProcessInstanceList()
 	if err != nil {
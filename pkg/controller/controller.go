@@ -19,7 +19,6 @@ package controller
 import (
 	"context"
 	"maps"
-	"slices"
 	"sync"
 
 	devicerepository "github.com/SENERGY-Platform/device-repository/lib/client"
@@ -40,6 +39,10 @@ type Controller struct {
 	userTokenProvider UserTokenProvider
 	adminAccess       *auth.OpenidToken
 	cleanupMux        sync.Mutex
+	reconciliationMux sync.Mutex
+	reconciliation    *model.ReleaseReconciliationReport
+
+	runningReleaseCreations sync.Map //ids of releases created by this process
 }
 
 type Permissions = permclient.Client
@@ -47,7 +50,11 @@ type Permissions = permclient.Client
 type Camunda interface {
 	DeployRelease(owner string, release model.SmartServiceReleaseExtended) (err error, isInvalidCamundaDeployment bool)
 	RemoveRelease(id string) error
+	IsReleaseDeployed(id string) (bool, error)
+	GetDeployedReleaseKeys() (keys []string, err error)
+	ReleaseIdToKey(id string) string
 	Start(result model.SmartServiceInstance) error
+	GetStartForm(instance model.SmartServiceInstance) (model.CamundaStartForm, error)
 	CheckInstanceReady(smartServiceInstanceId string) (finished bool, missing bool, err error)
 	StopInstance(smartServiceInstanceId string) error
 	DeleteInstance(instance model.HistoricProcessInstance) (err error)
@@ -87,30 +94,72 @@ func New(ctx context.Context, config configuration.Config, db Database, permissi
 		return nil, err
 	}
 
+	topicDesc.Id = config.SmartServiceDesignPermissionsTopic
+	_, err, _ = permissions.SetTopic(permclient.InternalAdminToken, topicDesc)
+	if err != nil {
+		return nil, err
+	}
+
 	instances, _, err, _ := db.ListInstances("", model.InstanceQueryOptions{})
 	if err != nil {
 		return nil, err
 	}
+	err = migrateInstanceStates(db, instances)
+	if err != nil {
+		return nil, err
+	}
+	err = failInterruptedInstanceMigrationJobs(db)
+	if err != nil {
+		return nil, err
+	}
+	err = failInterruptedReleaseCreations(db)
+	if err != nil {
+		return nil, err
+	}
+	instanceOwners := map[string]string{}
+	for _, instance := range instances {
+		instanceOwners[instance.Id] = instance.UserId
+	}
+	err = syncPermissionResources(permissions, config.SmartServiceInstancePermissionsTopic, instanceOwners)
+	if err != nil {
+		return nil, err
+	}
 
-	permResources, err, _ := permissions.ListResourcesWithAdminPermission(permclient.InternalAdminToken, config.SmartServiceInstancePermissionsTopic, permclient.ListOptions{})
+	designs, err, _ := db.ListDesigns("", model.DesignQueryOptions{})
 	if err != nil {
 		return nil, err
 	}
+	designOwners := map[string]string{}
+	for _, design := range designs {
+		designOwners[design.Id] = design.UserId
+	}
+	err = syncPermissionResources(permissions, config.SmartServiceDesignPermissionsTopic, designOwners)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctrl, nil
+}
+
+// syncPermissionResources gives owners of elements missing in permissions v2 full rights
+// and removes permission resources of elements that no longer exist
+func syncPermissionResources(permissions Permissions, topic string, owners map[string]string) error {
+	permResources, err, _ := permissions.ListResourcesWithAdminPermission(permclient.InternalAdminToken, topic, permclient.ListOptions{})
+	if err != nil {
+		return err
+	}
 	permResouceMap := map[string]permclient.Resource{}
 	for _, permResource := range permResources {
 		permResouceMap[permResource.Id] = permResource
 	}
 
-	dbIds := []string{}
-	for _, instance := range instances {
-		dbIds = append(dbIds, instance.Id)
-
-		_, ok := permResouceMap[instance.Id]
+	for id, owner := range owners {
+		_, ok := permResouceMap[id]
 		if !ok {
 			// missing in permissions v2
 			perm := permclient.ResourcePermissions{
 				UserPermissions: map[string]permclient.PermissionsMap{
-					instance.UserId: permclient.PermissionsMap{
+					owner: permclient.PermissionsMap{
 						Administrate: true,
 						Write:        true,
 						Read:         true,
@@ -118,25 +167,22 @@ func New(ctx context.Context, config configuration.Config, db Database, permissi
 					},
 				},
 			}
-			_, err, _ = permissions.SetPermission(permclient.InternalAdminToken, config.SmartServiceInstancePermissionsTopic, instance.Id, perm)
+			_, err, _ = permissions.SetPermission(permclient.InternalAdminToken, topic, id, perm)
 			if err != nil {
-				return nil, err
+				return err
 			}
 		}
 	}
 
-	permResouceIds := maps.Keys(permResouceMap)
-
-	for permResouceId := range permResouceIds {
-		if !slices.Contains(dbIds, permResouceId) {
-			err, _ = permissions.RemoveResource(permclient.InternalAdminToken, config.SmartServiceInstancePermissionsTopic, permResouceId)
+	for permResouceId := range maps.Keys(permResouceMap) {
+		if _, ok := owners[permResouceId]; !ok {
+			err, _ = permissions.RemoveResource(permclient.InternalAdminToken, topic, permResouceId)
 			if err != nil {
-				return nil, err
+				return err
 			}
 		}
 	}
-
-	return ctrl, nil
+	return nil
 }
 
 func (this *Controller) GetNewId() string {
@@ -20,20 +20,33 @@ import "github.com/SENERGY-Platform/smart-service-repository/pkg/model"
 
 type Database interface {
 	DesignsInterface
+	DesignRevisionsInterface
 	ModuleInterface
 	InstanceInterface
 	ReleaseInterface
 	MaintenanceInterface
 	VariableInterface
+	InstanceMigrationInterface
+	ReleaseCreationInterface
+	InstanceHistoryInterface
 }
 
 type DesignsInterface interface {
 	GetDesign(id string, userId string) (model.SmartServiceDesign, error, int)
 	SetDesign(element model.SmartServiceDesign) (error, int)
+	SetDesignIfRevision(element model.SmartServiceDesign, expectedRevision int64) (error, int)
 	DeleteDesign(id string, userId string) (error, int)
 	ListDesigns(userId string, query model.DesignQueryOptions) ([]model.SmartServiceDesign, error, int)
 }
 
+type DesignRevisionsInterface interface {
+	AddDesignRevision(element model.SmartServiceDesignRevision) (error, int)
+	GetDesignRevision(designId string, revision int64) (model.SmartServiceDesignRevision, error, int)
+	ListDesignRevisions(designId string, query model.DesignRevisionQueryOptions) ([]model.SmartServiceDesignRevision, error, int)
+	DeleteDesignRevisions(designId string) (error, int)
+	DeleteDesignRevision(designId string, revision int64) (error, int)
+}
+
 type ModuleInterface interface {
 	SetModule(element model.SmartServiceModule) (error, int)
 	SetModules(element []model.SmartServiceModule) (error, int)
@@ -49,8 +62,14 @@ type InstanceInterface interface {
 	GetInstance(id string, userId string) (model.SmartServiceInstance, error, int)
 	DeleteInstance(id string, userId string) (error, int)
 	SetInstance(element model.SmartServiceInstance) (error, int)
+	SetInstanceIfUpdatedAt(element model.SmartServiceInstance, expectedUpdatedAt int64) (error, int)
+	SetInstanceState(id string, expectedState string, state string, stateChangedAt int64) error
 	ListInstances(userId string, query model.InstanceQueryOptions) (result []model.SmartServiceInstance, total int64, err error, code int)
 	ListInstancesOfRelease(userId string, releaseId string) (result []model.SmartServiceInstance, err error, code int)
+	ListInstancesOfNewRelease(releaseId string) (result []model.SmartServiceInstance, err error, code int)
+	ListExpiredTestInstances(before int64) (result []model.SmartServiceInstance, err error, code int)
+	ListInstanceIdsOfReleases(releaseIds []string) (result []string, err error, code int)
+	GetReleaseInstanceStats(releaseIds []string, instanceIds []string) (result map[string]model.ReleaseInstanceStats, err error, code int)
 }
 
 type ReleaseInterface interface {
@@ -59,6 +78,7 @@ type ReleaseInterface interface {
 
 	GetRelease(id string, withMarked bool) (model.SmartServiceReleaseExtended, error, int)
 	ListReleases(options model.ListReleasesOptions) ([]model.SmartServiceReleaseExtended, int64, error)
+	GetReleaseFacets(options model.ListReleasesOptions) (model.ReleaseFacets, error)
 	GetReleasesByDesignId(designId string) ([]model.SmartServiceReleaseExtended, error)
 	GetPreviousReleases(releaseId string) (result []model.SmartServiceReleaseExtended, err error)
 
@@ -66,6 +86,8 @@ type ReleaseInterface interface {
 	DeleteRelease(id string) (error, int)
 
 	GetMarkedReleases() (markedAsDeleted []model.SmartServiceReleaseExtended, markedAsUnfinished []model.SmartServiceReleaseExtended, err error)
+	GetReleasesByLifecycleState(lifecycleState string) ([]model.SmartServiceReleaseExtended, error)
+	GetAllReleases() (finished []model.SmartServiceReleaseExtended, marked []model.SmartServiceReleaseExtended, err error)
 }
 
 type MaintenanceInterface interface {
@@ -80,3 +102,24 @@ type VariableInterface interface {
 	ListVariables(instanceId string, userId string, query model.VariableQueryOptions) (result []model.SmartServiceInstanceVariable, err error, code int)
 	ListAllVariables(query model.VariableQueryOptions) (result []model.SmartServiceInstanceVariable, err error, code int)
 }
+
+type InstanceMigrationInterface interface {
+	SetInstanceMigrationJob(element model.InstanceMigrationJob) (error, int)
+	GetInstanceMigrationJob(id string) (model.InstanceMigrationJob, error, int)
+	ListInstanceMigrationJobs(releaseId string) ([]model.InstanceMigrationJob, error, int)
+	ListInstanceMigrationJobsByStatus(status string) ([]model.InstanceMigrationJob, error, int)
+}
+
+type ReleaseCreationInterface interface {
+	SetReleaseCreation(element model.SmartServiceReleaseCreation) (error, int)
+	GetReleaseCreation(releaseId string) (model.SmartServiceReleaseCreation, error, int)
+	ListReleaseCreations(userId string, query model.ReleaseCreationQueryOptions) ([]model.SmartServiceReleaseCreation, error, int)
+	ListReleaseCreationsByStatus(status string) ([]model.SmartServiceReleaseCreation, error, int)
+	DeleteReleaseCreation(releaseId string) (error, int)
+}
+
+type InstanceHistoryInterface interface {
+	AddInstanceEvent(element model.SmartServiceInstanceEvent) (error, int)
+	ListInstanceEvents(instanceId string, query model.InstanceEventQueryOptions) ([]model.SmartServiceInstanceEvent, int64, error, int)
+	DeleteInstanceEvents(instanceId string) (error, int)
+}
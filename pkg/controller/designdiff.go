@@ -0,0 +1,245 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"strings"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/beevik/etree"
+)
+
+// DiffDesignBpmn compares two bpmn xml documents.
+// bpmn elements are matched by their id, start event form fields by their start event id and field id.
+func DiffDesignBpmn(fromXml string, toXml string) (elements model.DesignDiffSection, formFields model.DesignDiffSection, err error) {
+	defer func() {
+		if r := recover(); r != nil && err == nil {
+			err = errors.New(fmt.Sprint("Recovered Error: ", r, string(debug.Stack())))
+		}
+	}()
+	from := etree.NewDocument()
+	err = from.ReadFromString(fromXml)
+	if err != nil {
+		return elements, formFields, err
+	}
+	to := etree.NewDocument()
+	err = to.ReadFromString(toXml)
+	if err != nil {
+		return elements, formFields, err
+	}
+	elements = diffDesignElements(getBpmnElements(from), getBpmnElements(to), func(element *etree.Element) model.DesignDiffEntry {
+		return model.DesignDiffEntry{
+			Id:   element.SelectAttrValue("id", ""),
+			Type: element.FullTag(),
+			Name: element.SelectAttrValue("name", ""),
+		}
+	}, isBpmnElementContentIgnored)
+	formFields = diffDesignElements(getStartEventFormFields(from), getStartEventFormFields(to), func(element *etree.Element) model.DesignDiffEntry {
+		entry := model.DesignDiffEntry{
+			Id:   element.SelectAttrValue("id", ""),
+			Type: element.SelectAttrValue("type", ""),
+			Name: element.SelectAttrValue("label", ""),
+		}
+		if startEvent := findParentStartEvent(element); startEvent != nil {
+			entry.ParentId = startEvent.SelectAttrValue("id", "")
+		}
+		return entry
+	}, isFormFieldContentIgnored)
+	return elements, formFields, nil
+}
+
+// getBpmnElements returns all elements of the bpmn namespace with an id (processes, events, tasks, flows, messages, ...)
+func getBpmnElements(doc *etree.Document) (result map[string]*etree.Element) {
+	result = map[string]*etree.Element{}
+	var walk func(element *etree.Element)
+	walk = func(element *etree.Element) {
+		if element.Space == "bpmn" {
+			if id := element.SelectAttrValue("id", ""); id != "" {
+				result[id] = element
+			}
+		}
+		for _, child := range element.ChildElements() {
+			walk(child)
+		}
+	}
+	if doc.Root() != nil {
+		walk(doc.Root())
+	}
+	return result
+}
+
+func getStartEventFormFields(doc *etree.Document) (result map[string]*etree.Element) {
+	result = map[string]*etree.Element{}
+	for _, startEvent := range doc.FindElements("//bpmn:startEvent") {
+		startEventId := startEvent.SelectAttrValue("id", "")
+		for _, field := range startEvent.FindElements(".//camunda:formField") {
+			result[startEventId+"/"+field.SelectAttrValue("id", "")] = field
+		}
+	}
+	return result
+}
+
+func findParentStartEvent(element *etree.Element) *etree.Element {
+	for parent := element.Parent(); parent != nil; parent = parent.Parent() {
+		if parent.FullTag() == "bpmn:startEvent" {
+			return parent
+		}
+	}
+	return nil
+}
+
+// child bpmn elements are compared on their own, as are the form fields of start events
+func isBpmnElementContentIgnored(parent *etree.Element, child *etree.Element) bool {
+	if child.Space == "bpmn" && child.SelectAttrValue("id", "") != "" {
+		return true
+	}
+	return child.FullTag() == "camunda:formData" && findParentStartEvent(child) != nil
+}
+
+// properties are listed individually as changes
+func isFormFieldContentIgnored(parent *etree.Element, child *etree.Element) bool {
+	return parent.FullTag() == "camunda:formField" && child.FullTag() == "camunda:properties"
+}
+
+func diffDesignElements(from map[string]*etree.Element, to map[string]*etree.Element, toEntry func(element *etree.Element) model.DesignDiffEntry, ignoreContent func(parent *etree.Element, child *etree.Element) bool) (result model.DesignDiffSection) {
+	result = model.DesignDiffSection{
+		Added:   []model.DesignDiffEntry{},
+		Removed: []model.DesignDiffEntry{},
+		Changed: []model.DesignDiffEntry{},
+	}
+	for key, fromElement := range from {
+		toElement, ok := to[key]
+		if !ok {
+			result.Removed = append(result.Removed, toEntry(fromElement))
+			continue
+		}
+		changes := getDesignElementChanges(fromElement, toElement, ignoreContent)
+		if len(changes) > 0 {
+			entry := toEntry(toElement)
+			entry.Changes = changes
+			result.Changed = append(result.Changed, entry)
+		}
+	}
+	for key, toElement := range to {
+		if _, ok := from[key]; !ok {
+			result.Added = append(result.Added, toEntry(toElement))
+		}
+	}
+	for _, list := range [][]model.DesignDiffEntry{result.Added, result.Removed, result.Changed} {
+		slices.SortFunc(list, func(a, b model.DesignDiffEntry) int {
+			if c := strings.Compare(a.ParentId, b.ParentId); c != 0 {
+				return c
+			}
+			return strings.Compare(a.Id, b.Id)
+		})
+	}
+	return result
+}
+
+func getDesignElementChanges(from *etree.Element, to *etree.Element, ignoreContent func(parent *etree.Element, child *etree.Element) bool) (changes []string) {
+	if from.FullTag() != to.FullTag() {
+		changes = append(changes, "type")
+	}
+	fromAttr := getAttributeMap(from)
+	toAttr := getAttributeMap(to)
+	keys := []string{}
+	for key := range fromAttr {
+		keys = append(keys, key)
+	}
+	for key := range toAttr {
+		if _, ok := fromAttr[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		if key != "id" && fromAttr[key] != toAttr[key] {
+			changes = append(changes, key)
+		}
+	}
+	if from.FullTag() == "camunda:formField" {
+		fromProperties := getFormFieldProperties(from)
+		toProperties := getFormFieldProperties(to)
+		keys = []string{}
+		for key := range fromProperties {
+			keys = append(keys, key)
+		}
+		for key := range toProperties {
+			if _, ok := fromProperties[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			if fromProperties[key] != toProperties[key] {
+				changes = append(changes, "property:"+key)
+			}
+		}
+	}
+	if canonicalXmlContent(from, ignoreContent) != canonicalXmlContent(to, ignoreContent) {
+		changes = append(changes, "content")
+	}
+	return changes
+}
+
+func getAttributeMap(element *etree.Element) map[string]string {
+	result := map[string]string{}
+	for _, attr := range element.Attr {
+		result[attr.FullKey()] = attr.Value
+	}
+	return result
+}
+
+func getFormFieldProperties(field *etree.Element) map[string]string {
+	result := map[string]string{}
+	for _, property := range field.FindElements("./camunda:properties/camunda:property") {
+		result[property.SelectAttrValue("id", "")] = property.SelectAttrValue("value", "")
+	}
+	return result
+}
+
+// canonicalXmlContent returns a normalized representation of the children and text of an element
+// to be independent of attribute order and formatting
+func canonicalXmlContent(element *etree.Element, ignore func(parent *etree.Element, child *etree.Element) bool) string {
+	builder := strings.Builder{}
+	for _, token := range element.Child {
+		switch t := token.(type) {
+		case *etree.Element:
+			if ignore != nil && ignore(element, t) {
+				continue
+			}
+			attributes := slices.Clone(t.Attr)
+			slices.SortFunc(attributes, func(a, b etree.Attr) int {
+				return strings.Compare(a.FullKey(), b.FullKey())
+			})
+			builder.WriteString("<" + t.FullTag())
+			for _, attr := range attributes {
+				builder.WriteString(fmt.Sprintf(" %s=%q", attr.FullKey(), attr.Value))
+			}
+			builder.WriteString(">")
+			builder.WriteString(canonicalXmlContent(t, ignore))
+			builder.WriteString("</" + t.FullTag() + ">")
+		case *etree.CharData:
+			builder.WriteString(strings.TrimSpace(t.Data))
+		}
+	}
+	return builder.String()
+}
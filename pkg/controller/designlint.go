@@ -0,0 +1,69 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"net/http"
+	"runtime/debug"
+	"slices"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/beevik/etree"
+)
+
+func (this *Controller) LintDesign(token auth.Token, element model.SmartServiceDesign) (result model.DesignLintResult, err error, code int) {
+	if element.BpmnXml == "" && element.Id != "" {
+		element, err, code = this.GetDesign(token, element.Id)
+		if err != nil {
+			return result, err, code
+		}
+	}
+	result.Diagnostics = LintDesign(this.config, element.BpmnXml, this.GetCharacteristic)
+	result.Valid = !slices.ContainsFunc(result.Diagnostics, func(diagnostic model.DesignDiagnostic) bool {
+		return diagnostic.Severity == model.DesignDiagnosticSeverityError
+	})
+	return result, nil, http.StatusOK
+}
+
+// LintDesign runs the checks of ValidateDesign and parseDesignXmlForReleaseInfo
+// but reports every found problem instead of stopping at the first one.
+// getCharacteristic may be nil to skip the characteristic_id lookup.
+func LintDesign(config configuration.Config, xml string, getCharacteristic func(id string) (*model.Characteristic, error)) (result []model.DesignDiagnostic) {
+	diagnostics := &designDiagnostics{list: []model.DesignDiagnostic{}}
+	defer func() {
+		if r := recover(); r != nil {
+			config.GetLogger().Error("Recovered Error", "error", r, "stack", string(debug.Stack()))
+			diagnostics.errorf("internal_error", "", "", "Recovered Error: %v", r)
+			result = diagnostics.list
+		}
+	}()
+	if xml == "" {
+		diagnostics.errorf("missing_bpmn_xml", "", "", "missing bpmn xml")
+		return diagnostics.list
+	}
+	doc := etree.NewDocument()
+	err := doc.ReadFromString(xml)
+	if err != nil {
+		diagnostics.errorf("invalid_xml", "", "", "%v", err)
+		return diagnostics.list
+	}
+	validateStartEvents(doc, diagnostics)
+	parseReleaseParameters(doc, model.SmartServiceRelease{}, getCharacteristic, diagnostics)
+	return diagnostics.list
+}
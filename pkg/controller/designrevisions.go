@@ -0,0 +1,105 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"net/http"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+)
+
+func (this *Controller) ListDesignRevisions(token auth.Token, designId string, query model.DesignRevisionQueryOptions) (result []model.SmartServiceDesignRevision, err error, code int) {
+	_, err, code = this.GetDesign(token, designId)
+	if err != nil {
+		return result, err, code
+	}
+	return this.db.ListDesignRevisions(designId, query)
+}
+
+func (this *Controller) GetDesignRevision(token auth.Token, designId string, revision int64) (result model.SmartServiceDesignRevision, err error, code int) {
+	_, err, code = this.GetDesign(token, designId)
+	if err != nil {
+		return result, err, code
+	}
+	return this.db.GetDesignRevision(designId, revision)
+}
+
+func (this *Controller) DiffDesignRevisions(token auth.Token, designId string, fromRevision int64, toRevision int64) (result model.SmartServiceDesignDiff, err error, code int) {
+	from, err, code := this.GetDesignRevision(token, designId, fromRevision)
+	if err != nil {
+		return result, err, code
+	}
+	to, err, code := this.db.GetDesignRevision(designId, toRevision)
+	if err != nil {
+		return result, err, code
+	}
+	result = model.SmartServiceDesignDiff{
+		DesignId:     designId,
+		FromRevision: fromRevision,
+		ToRevision:   toRevision,
+	}
+	result.Elements, result.FormFields, err = DiffDesignBpmn(from.BpmnXml, to.BpmnXml)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return result, nil, http.StatusOK
+}
+
+// RestoreDesignRevision saves the content of the given revision as the newest revision of the design
+func (this *Controller) RestoreDesignRevision(token auth.Token, designId string, revision int64) (result model.SmartServiceDesign, err error, code int) {
+	design, err, code := this.GetDesign(token, designId)
+	if err != nil {
+		return result, err, code
+	}
+	old, err, code := this.db.GetDesignRevision(designId, revision)
+	if err != nil {
+		return result, err, code
+	}
+	design.Name = old.Name
+	design.Description = old.Description
+	design.BpmnXml = old.BpmnXml
+	design.SvgXml = old.SvgXml
+	return this.SetDesign(token, design)
+}
+
+// addDesignRevision stores the element as new immutable revision and returns its revision number.
+// concurrent updates of the same design result in a http.StatusConflict because revisions are unique per design.
+func (this *Controller) addDesignRevision(token auth.Token, element model.SmartServiceDesign) (revision int64, err error, code int) {
+	current, err, code := this.db.GetDesign(element.Id, "")
+	if err != nil && code != http.StatusNotFound {
+		return revision, err, code
+	}
+	if err == nil {
+		if current.Revision == 0 {
+			//design has been created before revisions where stored --> keep its current state as revision 0
+			err, code = this.db.AddDesignRevision(model.SmartServiceDesignRevision{SmartServiceDesign: current, Author: current.UserId})
+			if err != nil && code != http.StatusConflict {
+				return revision, err, code
+			}
+		}
+		revision = current.Revision + 1
+	} else {
+		revision = 1
+	}
+	element.Revision = revision
+	err, code = this.db.AddDesignRevision(model.SmartServiceDesignRevision{SmartServiceDesign: element, Author: token.GetUserId()})
+	if err != nil {
+		return revision, err, code
+	}
+	return revision, nil, http.StatusOK
+}
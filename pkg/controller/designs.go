@@ -23,20 +23,92 @@ import (
 	"runtime/debug"
 	"time"
 
+	"github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	perm_model "github.com/SENERGY-Platform/permissions-v2/pkg/model"
 	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
 	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
 	"github.com/beevik/etree"
 )
 
-func (this *Controller) ListDesigns(token auth.Token, query model.DesignQueryOptions) ([]model.SmartServiceDesign, error, int) {
-	return this.db.ListDesigns(token.GetUserId(), query)
+func (this *Controller) ListDesigns(token auth.Token, query model.DesignQueryOptions) (result []model.SmartServiceDesign, err error, code int) {
+	listOptions := client.ListOptions{}
+	if len(query.Ids) > 0 {
+		listOptions.Ids = query.Ids
+	}
+	accessibleIds, err, code := this.permissions.ListAccessibleResourceIds(token.Token, this.config.SmartServiceDesignPermissionsTopic, listOptions, client.Read)
+	if err != nil {
+		return result, err, code
+	}
+	if len(accessibleIds) == 0 {
+		return []model.SmartServiceDesign{}, nil, http.StatusOK
+	}
+	query.Ids = accessibleIds
+	query.UserId = token.GetUserId()
+	result, err, code = this.db.ListDesigns("", query)
+	if err != nil {
+		return result, err, code
+	}
+	err, code = this.fillDesignPermissions(token, result)
+	if err != nil {
+		return result, err, code
+	}
+	return result, nil, http.StatusOK
 }
 
 func (this *Controller) GetDesign(token auth.Token, id string) (result model.SmartServiceDesign, err error, code int) {
-	return this.db.GetDesign(id, token.GetUserId())
+	access, err, code := this.permissions.CheckPermission(token.Token, this.config.SmartServiceDesignPermissionsTopic, id, client.Read)
+	if err != nil {
+		return result, err, code
+	}
+	if !access {
+		return result, errors.New("missing design read access"), http.StatusForbidden
+	}
+	result, err, code = this.db.GetDesign(id, "")
+	if err != nil {
+		return result, err, code
+	}
+	arr := []model.SmartServiceDesign{result}
+	err, code = this.fillDesignPermissions(token, arr)
+	if err != nil {
+		return result, err, code
+	}
+	return arr[0], nil, http.StatusOK
 }
 
 func (this *Controller) SetDesign(token auth.Token, element model.SmartServiceDesign) (result model.SmartServiceDesign, err error, code int) {
+	return this.setDesign(token, element, nil)
+}
+
+// SetDesignWithPrecondition updates an existing design only if its revision equals expectedRevision (If-Match)
+func (this *Controller) SetDesignWithPrecondition(token auth.Token, element model.SmartServiceDesign, expectedRevision int64) (result model.SmartServiceDesign, err error, code int) {
+	return this.setDesign(token, element, &expectedRevision)
+}
+
+func (this *Controller) setDesign(token auth.Token, element model.SmartServiceDesign, expectedRevision *int64) (result model.SmartServiceDesign, err error, code int) {
+	existing, err, code := this.db.GetDesign(element.Id, "")
+	if err != nil && code != http.StatusNotFound {
+		return result, err, code
+	}
+	isNew := err != nil
+	if isNew && expectedRevision != nil {
+		return result, errors.New("design does not exist"), http.StatusPreconditionFailed
+	}
+	if !isNew {
+		access, err, code := this.permissions.CheckPermission(token.Token, this.config.SmartServiceDesignPermissionsTopic, element.Id, client.Write)
+		if err != nil {
+			return result, err, code
+		}
+		if !access {
+			return result, errors.New("missing design write access"), http.StatusForbidden
+		}
+		element.UserId = existing.UserId //shared designs keep their owner
+		element.Template = existing.Template
+		if expectedRevision != nil && existing.Revision != *expectedRevision {
+			return result, fmt.Errorf("design has been changed since revision %v (current revision %v)", *expectedRevision, existing.Revision), http.StatusPreconditionFailed
+		}
+	} else {
+		element.Template = false //only admins may flag templates with SetDesignTemplate
+	}
 	if element.Name == "" {
 		element.Name, err = this.getProcessModelName(element.BpmnXml)
 		if err != nil {
@@ -54,15 +126,165 @@ func (this *Controller) SetDesign(token auth.Token, element model.SmartServiceDe
 	if err != nil {
 		return result, err, code
 	}
-	err, code = this.db.SetDesign(element)
+	element.Revision, err, code = this.addDesignRevision(token, element)
+	if err != nil {
+		if expectedRevision != nil && code == http.StatusConflict {
+			code = http.StatusPreconditionFailed //concurrent update with the same expected revision
+		}
+		return result, err, code
+	}
+	if expectedRevision != nil {
+		err, code = this.db.SetDesignIfRevision(element, existing.Revision)
+	} else {
+		err, code = this.db.SetDesign(element)
+	}
+	if err != nil {
+		//the revision is stored first to detect concurrent updates by its unique index --> remove it if the design is not stored
+		err2, _ := this.db.DeleteDesignRevision(element.Id, element.Revision)
+		if err2 != nil {
+			this.config.GetLogger().Error("unable to remove revision of failed design update", "designId", element.Id, "revision", element.Revision, "error", err2)
+		}
+		return result, err, code
+	}
+	if isNew {
+		_, err, code = this.permissions.SetPermission(client.InternalAdminToken, this.config.SmartServiceDesignPermissionsTopic, element.Id, client.ResourcePermissions{
+			UserPermissions: map[string]client.PermissionsMap{
+				element.UserId: {
+					Read:         true,
+					Write:        true,
+					Execute:      true,
+					Administrate: true,
+				},
+			},
+		})
+		if err != nil {
+			return result, err, code
+		}
+	}
+	return this.GetDesign(token, element.Id)
+}
+
+func (this *Controller) CloneDesign(token auth.Token, id string) (result model.SmartServiceDesign, err error, code int) {
+	design, err, code := this.GetDesign(token, id)
+	if err != nil {
+		return result, err, code
+	}
+	return this.SetDesign(token, model.SmartServiceDesign{
+		Id:          this.GetNewId(),
+		UserId:      token.GetUserId(),
+		Name:        design.Name,
+		Description: design.Description,
+		BpmnXml:     design.BpmnXml,
+		SvgXml:      design.SvgXml,
+	})
+}
+
+func (this *Controller) CreateDesignFromRelease(token auth.Token, releaseId string) (result model.SmartServiceDesign, err error, code int) {
+	release, err, code := this.GetExtendedRelease(token, releaseId)
+	if err != nil {
+		return result, err, code
+	}
+	return this.SetDesign(token, model.SmartServiceDesign{
+		Id:          this.GetNewId(),
+		UserId:      token.GetUserId(),
+		Name:        release.Name,
+		Description: release.Description,
+		BpmnXml:     release.BpmnXml,
+		SvgXml:      release.SvgXml,
+	})
+}
+
+// SetDesignTemplate flags or unflags a design as template and grants/revokes read access for config.DesignTemplateRole.
+// the caller is expected to be an admin.
+func (this *Controller) SetDesignTemplate(token auth.Token, id string, template bool) (result model.SmartServiceDesign, err error, code int) {
+	design, err, code := this.db.GetDesign(id, "")
+	if err != nil {
+		return result, err, code
+	}
+	resource, err, code := this.permissions.GetResource(client.InternalAdminToken, this.config.SmartServiceDesignPermissionsTopic, id)
+	if err != nil {
+		return result, err, code
+	}
+	permissions := resource.ResourcePermissions
+	if permissions.RolePermissions == nil {
+		permissions.RolePermissions = map[string]client.PermissionsMap{}
+	}
+	if template {
+		permissions.RolePermissions[this.config.DesignTemplateRole] = client.PermissionsMap{Read: true}
+	} else {
+		delete(permissions.RolePermissions, this.config.DesignTemplateRole)
+	}
+	_, err, code = this.permissions.SetPermission(client.InternalAdminToken, this.config.SmartServiceDesignPermissionsTopic, id, permissions)
+	if err != nil {
+		return result, err, code
+	}
+	design.Template = template
+	err, code = this.db.SetDesign(design)
 	if err != nil {
 		return result, err, code
 	}
-	return this.db.GetDesign(element.Id, token.GetUserId())
+	return this.GetDesign(token, id)
 }
 
 func (this *Controller) DeleteDesign(token auth.Token, id string) (error, int) {
-	return this.db.DeleteDesign(id, token.GetUserId())
+	_, err, code := this.db.GetDesign(id, "")
+	if err != nil {
+		if code == http.StatusNotFound {
+			return nil, http.StatusOK
+		}
+		return err, code
+	}
+	access, err, code := this.permissions.CheckPermission(token.Token, this.config.SmartServiceDesignPermissionsTopic, id, client.Administrate)
+	if err != nil {
+		return err, code
+	}
+	if !access {
+		return errors.New("missing design administrate access"), http.StatusForbidden
+	}
+	err, code = this.db.DeleteDesign(id, "")
+	if err != nil {
+		return err, code
+	}
+	err, code = this.db.DeleteDesignRevisions(id)
+	if err != nil {
+		return err, code
+	}
+	err, code = this.permissions.RemoveResource(client.InternalAdminToken, this.config.SmartServiceDesignPermissionsTopic, id)
+	if err != nil {
+		return err, code
+	}
+	return nil, http.StatusOK
+}
+
+func (this *Controller) fillDesignPermissions(token auth.Token, designs []model.SmartServiceDesign) (err error, code int) {
+	ids := []string{}
+	for _, design := range designs {
+		ids = append(ids, design.Id)
+	}
+	perms, err, code := this.permissions.ListComputedPermissions(token.Token, this.config.SmartServiceDesignPermissionsTopic, ids)
+	if err != nil {
+		return err, code
+	}
+	permsById := map[string]perm_model.ComputedPermissions{}
+	for _, perm := range perms {
+		permsById[perm.Id] = perm
+	}
+	for i := range designs {
+		perm, ok := permsById[designs[i].Id]
+		if !ok {
+			continue
+		}
+		designs[i].PermissionsInfo = model.PermissionsInfo{
+			Shared: designs[i].UserId != token.GetUserId(),
+			Permissions: map[string]bool{
+				"read":         perm.Read,
+				"write":        perm.Write,
+				"execute":      perm.Execute,
+				"administrate": perm.Administrate,
+			},
+		}
+	}
+	return nil, http.StatusOK
 }
 
 func (this *Controller) ValidateDesign(token auth.Token, element model.SmartServiceDesign) (err error, code int) {
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/google/uuid"
+)
+
+// GetInstanceHistory returns the events of an instance; the history is kept after the instance has been deleted
+// but is then only readable by admins, because the instance permissions are removed with the instance
+func (this *Controller) GetInstanceHistory(token auth.Token, instanceId string, query model.InstanceEventQueryOptions) (result []model.SmartServiceInstanceEvent, total int64, err error, code int) {
+	if !token.IsAdmin() {
+		access, err, code := this.permissions.CheckPermission(token.Token, this.config.SmartServiceInstancePermissionsTopic, instanceId, client.Read)
+		if err != nil {
+			return result, total, err, code
+		}
+		if !access {
+			return result, total, errors.New("missing instance read access"), http.StatusForbidden
+		}
+	}
+	return this.db.ListInstanceEvents(instanceId, query)
+}
+
+// addInstanceEvent stores event with a new id and the current time; errors are logged because the history must not block the instance operation
+func (this *Controller) addInstanceEvent(event model.SmartServiceInstanceEvent) {
+	event.Id = uuid.NewString()
+	event.Time = time.Now().UnixMilli()
+	err, _ := this.db.AddInstanceEvent(event)
+	if err != nil {
+		this.config.GetLogger().Error("unable to store instance event", "instanceId", event.InstanceId, "type", event.Type, "error", err)
+	}
+}
+
+func userActor(token auth.Token) model.InstanceEventActor {
+	return model.InstanceEventActor{UserId: token.GetUserId()}
+}
+
+func processInstanceActor(processInstanceId string) model.InstanceEventActor {
+	return model.InstanceEventActor{ProcessInstanceId: processInstanceId}
+}
+
+// getChangedParameterIds returns the sorted ids of parameters that have been added, removed or changed their value
+func getChangedParameterIds(oldParameters []model.SmartServiceParameter, newParameters []model.SmartServiceParameter) (result []string) {
+	oldIndex := map[string]model.SmartServiceParameter{}
+	for _, param := range oldParameters {
+		oldIndex[param.Id] = param
+	}
+	newIndex := map[string]model.SmartServiceParameter{}
+	for _, param := range newParameters {
+		newIndex[param.Id] = param
+	}
+	for id, param := range newIndex {
+		oldParam, ok := oldIndex[id]
+		if !ok || !reflect.DeepEqual(oldParam.Value, param.Value) {
+			result = append(result, id)
+		}
+	}
+	for id := range oldIndex {
+		if _, ok := newIndex[id]; !ok {
+			result = append(result, id)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,243 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"time"
+
+	"github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+)
+
+// MigrateInstances starts a background job, which redeploys every instance with NewReleaseId == releaseId onto the release.
+// only instances, the user may administrate, are migrated. parameters are carried over if they are compatible with the release.
+func (this *Controller) MigrateInstances(token auth.Token, releaseId string) (result model.InstanceMigrationJob, err error, code int) {
+	release, err, code := this.GetExtendedRelease(token, releaseId)
+	if err != nil {
+		return result, err, code
+	}
+	access, err, _ := this.permissions.CheckPermission(token.Jwt(), this.config.SmartServiceReleasePermissionsTopic, releaseId, client.Execute)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !access {
+		return result, errors.New("missing release execute access"), http.StatusForbidden
+	}
+	err, code = checkReleaseUsable(release.SmartServiceRelease)
+	if err != nil {
+		return result, err, code
+	}
+	instances, err, code := this.db.ListInstancesOfNewRelease(releaseId)
+	if err != nil {
+		return result, err, code
+	}
+	accessibleIds := []string{}
+	if len(instances) > 0 {
+		ids := []string{}
+		for _, instance := range instances {
+			ids = append(ids, instance.Id)
+		}
+		accessibleIds, err, code = this.permissions.ListAccessibleResourceIds(token.Jwt(), this.config.SmartServiceInstancePermissionsTopic, client.ListOptions{Ids: ids}, client.Administrate, client.Write)
+		if err != nil {
+			return result, err, code
+		}
+	}
+	result = model.InstanceMigrationJob{
+		Id:        this.GetNewId(),
+		UserId:    token.GetUserId(),
+		ReleaseId: releaseId,
+		Status:    model.InstanceMigrationJobStatusRunning,
+		CreatedAt: time.Now().Unix(),
+		Results:   []model.InstanceMigrationResult{},
+	}
+	for _, instance := range instances {
+		if slices.Contains(accessibleIds, instance.Id) {
+			result.Results = append(result.Results, model.InstanceMigrationResult{
+				InstanceId:        instance.Id,
+				InstanceName:      instance.Name,
+				PreviousReleaseId: instance.ReleaseId,
+				Status:            model.InstanceMigrationStatusPending,
+			})
+		}
+	}
+	if len(result.Results) == 0 {
+		result.Status = model.InstanceMigrationJobStatusFinished
+		result.FinishedAt = result.CreatedAt
+	}
+	err, code = this.db.SetInstanceMigrationJob(result)
+	if err != nil {
+		return result, err, code
+	}
+	if result.Status == model.InstanceMigrationJobStatusRunning {
+		go this.runInstanceMigrationJob(result, release)
+	}
+	return result, nil, http.StatusAccepted
+}
+
+func (this *Controller) GetInstanceMigrationJob(token auth.Token, releaseId string, jobId string) (result model.InstanceMigrationJob, err error, code int) {
+	_, err, code = this.GetRelease(token, releaseId)
+	if err != nil {
+		return result, err, code
+	}
+	result, err, code = this.db.GetInstanceMigrationJob(jobId)
+	if err != nil {
+		return result, err, code
+	}
+	if result.ReleaseId != releaseId {
+		return model.InstanceMigrationJob{}, errors.New("instance migration job not found"), http.StatusNotFound
+	}
+	if result.UserId != token.GetUserId() && !token.IsAdmin() {
+		return model.InstanceMigrationJob{}, errors.New("access denied"), http.StatusForbidden
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) ListInstanceMigrationJobs(token auth.Token, releaseId string) (result []model.InstanceMigrationJob, err error, code int) {
+	_, err, code = this.GetRelease(token, releaseId)
+	if err != nil {
+		return result, err, code
+	}
+	jobs, err, code := this.db.ListInstanceMigrationJobs(releaseId)
+	if err != nil {
+		return result, err, code
+	}
+	result = []model.InstanceMigrationJob{}
+	for _, job := range jobs {
+		if job.UserId == token.GetUserId() || token.IsAdmin() {
+			result = append(result, job)
+		}
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) runInstanceMigrationJob(job model.InstanceMigrationJob, release model.SmartServiceReleaseExtended) {
+	defer func() {
+		if r := recover(); r != nil {
+			this.config.GetLogger().Error("Recovered Error in runInstanceMigrationJob", "error", r, "stack", string(debug.Stack()))
+		}
+	}()
+	for i, entry := range job.Results {
+		job.Results[i] = this.migrateInstance(entry, release)
+		err, _ := this.db.SetInstanceMigrationJob(job)
+		if err != nil {
+			this.config.GetLogger().Error("unable to store instance migration job", "error", err, "job", job.Id)
+		}
+	}
+	job.Status = model.InstanceMigrationJobStatusFinished
+	job.FinishedAt = time.Now().Unix()
+	err, _ := this.db.SetInstanceMigrationJob(job)
+	if err != nil {
+		this.config.GetLogger().Error("unable to store instance migration job", "error", err, "job", job.Id)
+	}
+}
+
+// failInterruptedInstanceMigrationJobs marks jobs as failed, which were still running when the service stopped
+func failInterruptedInstanceMigrationJobs(db Database) error {
+	jobs, err, _ := db.ListInstanceMigrationJobsByStatus(model.InstanceMigrationJobStatusRunning)
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		job.Status = model.InstanceMigrationJobStatusFailed
+		job.Error = "instance migration job has been interrupted"
+		job.FinishedAt = time.Now().Unix()
+		for i, entry := range job.Results {
+			if entry.Status == model.InstanceMigrationStatusPending {
+				job.Results[i].Status = model.InstanceMigrationStatusFailed
+				job.Results[i].Error = job.Error
+			}
+		}
+		err, _ = db.SetInstanceMigrationJob(job)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateInstance redeploys the instance in the name of its owner
+func (this *Controller) migrateInstance(entry model.InstanceMigrationResult, release model.SmartServiceReleaseExtended) model.InstanceMigrationResult {
+	entry.Status = model.InstanceMigrationStatusFailed
+	instance, err, _ := this.db.GetInstance(entry.InstanceId, "")
+	if err != nil {
+		entry.Error = err.Error()
+		return entry
+	}
+	if instance.NewReleaseId != release.Id {
+		entry.Error = "instance has been changed since the start of the migration"
+		return entry
+	}
+	previous, err, _ := this.db.GetRelease(instance.ReleaseId, true)
+	if err != nil {
+		entry.Error = err.Error()
+		return entry
+	}
+	parameters, missing := MigrateInstanceParameters(instance.Parameters, previous.ParsedInfo, release.ParsedInfo)
+	if len(missing) > 0 {
+		entry.Status = model.InstanceMigrationStatusNeedsInput
+		entry.MissingParameterIds = missing
+		return entry
+	}
+	token, err := this.userTokenProvider(instance.UserId)
+	if err != nil {
+		entry.Error = err.Error()
+		return entry
+	}
+	_, err, _ = this.RedeployInstance(token, instance.Id, parameters, release.Id)
+	if err != nil {
+		entry.Error = err.Error()
+		return entry
+	}
+	entry.Status = model.InstanceMigrationStatusMigrated
+	return entry
+}
+
+// MigrateInstanceParameters carries parameters over from one release to another.
+// parameters are matched by id and dropped if they are unknown to the new release or changed in an incompatible way (see DiffReleaseInfo).
+// missing required parameters are filled with their default value; if none exists, their id is returned in missingParameterIds.
+func MigrateInstanceParameters(parameters []model.SmartServiceParameter, from model.SmartServiceReleaseInfo, to model.SmartServiceReleaseInfo) (result []model.SmartServiceParameter, missingParameterIds []string) {
+	reenter := DiffReleaseInfo(from, to).ReenterParameterIds
+	result = []model.SmartServiceParameter{}
+	for _, param := range parameters {
+		index := slices.IndexFunc(to.ParameterDescriptions, func(desc model.ParameterDescription) bool { return desc.Id == param.Id })
+		if index < 0 || slices.Contains(reenter, param.Id) || to.ParameterDescriptions[index].AutoSelectAll {
+			continue
+		}
+		param.Label = to.ParameterDescriptions[index].Label
+		result = append(result, param)
+	}
+	for _, desc := range to.ParameterDescriptions {
+		if desc.Optional || desc.AutoSelectAll || slices.ContainsFunc(result, func(param model.SmartServiceParameter) bool { return param.Id == desc.Id }) {
+			continue
+		}
+		if desc.DefaultValue != nil {
+			result = append(result, model.SmartServiceParameter{
+				Id:    desc.Id,
+				Value: desc.DefaultValue,
+				Label: desc.Label,
+			})
+			continue
+		}
+		missingParameterIds = append(missingParameterIds, desc.Id)
+	}
+	return result, missingParameterIds
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/database/mongo"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/docker"
+)
+
+func TestFailInterruptedInstanceMigrationJobs(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config, err := configuration.Load("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	host, port, err := docker.MongoDB(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	config.MongoUrl = "mongodb://" + host + ":" + port
+
+	db, err := mongo.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, job := range []model.InstanceMigrationJob{
+		{
+			Id:        "running",
+			ReleaseId: "release",
+			Status:    model.InstanceMigrationJobStatusRunning,
+			Results: []model.InstanceMigrationResult{
+				{InstanceId: "migrated", Status: model.InstanceMigrationStatusMigrated},
+				{InstanceId: "pending", Status: model.InstanceMigrationStatusPending},
+			},
+		},
+		{
+			Id:         "finished",
+			ReleaseId:  "release",
+			Status:     model.InstanceMigrationJobStatusFinished,
+			FinishedAt: 42,
+			Results:    []model.InstanceMigrationResult{},
+		},
+	} {
+		err, _ = db.SetInstanceMigrationJob(job)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	err = failInterruptedInstanceMigrationJobs(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	job, err, _ := db.GetInstanceMigrationJob("running")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if job.Status != model.InstanceMigrationJobStatusFailed || job.Error == "" || job.FinishedAt == 0 {
+		t.Errorf("%#v", job)
+	}
+	if len(job.Results) != 2 || job.Results[0].Status != model.InstanceMigrationStatusMigrated || job.Results[1].Status != model.InstanceMigrationStatusFailed {
+		t.Errorf("%#v", job.Results)
+	}
+
+	job, err, _ = db.GetInstanceMigrationJob("finished")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if job.Status != model.InstanceMigrationJobStatusFinished || job.FinishedAt != 42 {
+		t.Errorf("%#v", job)
+	}
+}
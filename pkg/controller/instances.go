@@ -36,45 +36,51 @@ import (
 )
 
 func (this *Controller) CreateInstance(token auth.Token, releaseId string, instanceInfo model.SmartServiceInstanceInit) (result model.SmartServiceInstance, err error, code int) {
-	if instanceInfo.Name == "" {
-		return result, errors.New("missing name"), http.StatusBadRequest
+	return this.createInstance(token, releaseId, instanceInfo, 0)
+}
+
+// CreateTestInstance creates an instance which is hidden in the default instance list and removed by the cleanup after ttl.
+// ttl defaults to and may not exceed the configured test_instance_ttl.
+func (this *Controller) CreateTestInstance(token auth.Token, releaseId string, instanceInfo model.SmartServiceInstanceInit, ttl time.Duration) (result model.SmartServiceInstance, err error, code int) {
+	maxTtl := this.config.TestInstanceTtl.GetDuration()
+	if maxTtl <= 0 {
+		return result, errors.New("test instances are disabled"), http.StatusNotImplemented
 	}
-	if releaseId == "" {
-		return result, errors.New("invalid release id"), http.StatusBadRequest
+	if ttl == 0 {
+		ttl = maxTtl
 	}
-	access, err, _ := this.permissions.CheckPermission(token.Jwt(), this.config.SmartServiceReleasePermissionsTopic, releaseId, client.Execute)
-	if err != nil {
-		return result, err, http.StatusInternalServerError
+	if ttl < 0 || ttl > maxTtl {
+		return result, fmt.Errorf("ttl must be positive and may not exceed %v", maxTtl), http.StatusBadRequest
 	}
-	if !access {
-		return result, errors.New("missing release access"), http.StatusForbidden
-	}
-	release, err, code := this.db.GetRelease(releaseId, false)
+	return this.createInstance(token, releaseId, instanceInfo, ttl)
+}
+
+// CreateInstanceDryRun runs the checks, the parameter validation and the auto-select expansion of CreateInstance
+// and returns the form which would be submitted to camunda; nothing is persisted or started
+func (this *Controller) CreateInstanceDryRun(token auth.Token, releaseId string, instanceInfo model.SmartServiceInstanceInit) (result model.CamundaStartForm, err error, code int) {
+	instance, paramListWithAutoSelect, err, code := this.prepareInstance(token, releaseId, instanceInfo)
 	if err != nil {
 		return result, err, code
 	}
+	instance.SmartServiceInstanceInit.Parameters = this.replaceLongParameterWithVariableReference(paramListWithAutoSelect)
+	result, err = this.camunda.GetStartForm(instance)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return result, nil, http.StatusOK
+}
 
+// createInstance creates a test instance if testTtl > 0
+func (this *Controller) createInstance(token auth.Token, releaseId string, instanceInfo model.SmartServiceInstanceInit, testTtl time.Duration) (result model.SmartServiceInstance, err error, code int) {
 	paramListWithoutAutoSelect := instanceInfo.Parameters
-
-	paramListWithAutoSelect, err, code := this.appendAutoSelectParams(token, instanceInfo.Parameters, release.ParsedInfo.ParameterDescriptions)
+	result, paramListWithAutoSelect, err, code := this.prepareInstance(token, releaseId, instanceInfo)
 	if err != nil {
 		return result, err, code
 	}
-
-	//store without auto_select_all parameter
-	result = model.SmartServiceInstance{
-		SmartServiceInstanceInit: instanceInfo,
-		Id:                       uuid.NewString(),
-		UserId:                   token.GetUserId(),
-		DesignId:                 release.DesignId,
-		ReleaseId:                release.Id,
-		Ready:                    false,
-		Error:                    "",
-		NewReleaseId:             release.NewReleaseId,
-		UpdatedAt:                time.Now().Unix(),
-		CreatedAt:                time.Now().Unix(),
+	if testTtl > 0 {
+		result.Test = true
+		result.ExpiresAt = time.Now().Add(testTtl).Unix()
 	}
-	result.UpdatedAt = time.Now().Unix()
 
 	this.cleanupMux.Lock()
 	defer this.cleanupMux.Unlock()
@@ -120,6 +126,17 @@ func (this *Controller) CreateInstance(token auth.Token, releaseId string, insta
 		}
 		return result, err, http.StatusInternalServerError
 	}
+	err = this.updateInstanceState(&result, model.InstanceStateStarting)
+	if err != nil {
+		this.config.GetLogger().Error("error in CreateInstance", "error", err, "stack", string(debug.Stack()))
+	}
+
+	this.addInstanceEvent(model.SmartServiceInstanceEvent{
+		InstanceId: result.Id,
+		Type:       model.InstanceEventCreated,
+		Actor:      userActor(token),
+		ReleaseId:  result.ReleaseId,
+	})
 
 	//return result without auto_select_all parameters
 	result.SmartServiceInstanceInit.Parameters = paramListWithoutAutoSelect
@@ -135,6 +152,65 @@ func (this *Controller) CreateInstance(token auth.Token, releaseId string, insta
 	return result, nil, http.StatusOK
 }
 
+// prepareInstance checks the release access and the parameters and returns the instance to be created
+// with the parameters expanded by auto_select_all parameters; the returned instance contains the parameters without auto_select_all
+func (this *Controller) prepareInstance(token auth.Token, releaseId string, instanceInfo model.SmartServiceInstanceInit) (result model.SmartServiceInstance, paramListWithAutoSelect []model.SmartServiceParameter, err error, code int) {
+	if instanceInfo.Name == "" {
+		return result, nil, errors.New("missing name"), http.StatusBadRequest
+	}
+	if releaseId == "" {
+		return result, nil, errors.New("invalid release id"), http.StatusBadRequest
+	}
+	access, err, _ := this.permissions.CheckPermission(token.Jwt(), this.config.SmartServiceReleasePermissionsTopic, releaseId, client.Execute)
+	if err != nil {
+		return result, nil, err, http.StatusInternalServerError
+	}
+	if !access {
+		return result, nil, errors.New("missing release access"), http.StatusForbidden
+	}
+	release, err, code := this.db.GetRelease(releaseId, false)
+	if err != nil {
+		return result, nil, err, code
+	}
+	err, code = checkReleaseVisibility(token, release.SmartServiceRelease)
+	if err != nil {
+		return result, nil, err, code
+	}
+	err, code = checkReleaseUsable(release.SmartServiceRelease)
+	if err != nil {
+		return result, nil, err, code
+	}
+	err, code = this.validateInstanceParameters(token, release.ParsedInfo.ParameterDescriptions, instanceInfo.Parameters)
+	if err != nil {
+		return result, nil, err, code
+	}
+
+	paramListWithAutoSelect, err, code = this.appendAutoSelectParams(token, instanceInfo.Parameters, release.ParsedInfo.ParameterDescriptions)
+	if err != nil {
+		return result, nil, err, code
+	}
+
+	//store without auto_select_all parameter
+	result = model.SmartServiceInstance{
+		SmartServiceInstanceInit: instanceInfo,
+		Id:                       uuid.NewString(),
+		UserId:                   token.GetUserId(),
+		DesignId:                 release.DesignId,
+		ReleaseId:                release.Id,
+		Ready:                    false,
+		Error:                    "",
+		NewReleaseId:             release.NewReleaseId,
+		UpdatedAt:                time.Now().Unix(),
+		CreatedAt:                time.Now().Unix(),
+	}
+	result.UpdatedAt = time.Now().Unix()
+	err = result.SetState(model.InstanceStateCreating)
+	if err != nil {
+		return result, nil, err, http.StatusInternalServerError
+	}
+	return result, paramListWithAutoSelect, nil, http.StatusOK
+}
+
 func (this *Controller) appendAutoSelectParams(token auth.Token, parameters []model.SmartServiceParameter, paramDescriptions []model.ParameterDescription) (result []model.SmartServiceParameter, err error, code int) {
 	result = []model.SmartServiceParameter{}
 	result = append(result, parameters...)
@@ -162,6 +238,15 @@ func (this *Controller) appendAutoSelectParams(token auth.Token, parameters []mo
 }
 
 func (this *Controller) UpdateInstanceInfo(token auth.Token, id string, element model.SmartServiceInstanceInfo) (result model.SmartServiceInstance, err error, code int) {
+	return this.updateInstanceInfo(token, id, element, nil)
+}
+
+// UpdateInstanceInfoWithPrecondition updates the instance info only if the instance updated_at value equals expectedUpdatedAt (If-Match)
+func (this *Controller) UpdateInstanceInfoWithPrecondition(token auth.Token, id string, element model.SmartServiceInstanceInfo, expectedUpdatedAt int64) (result model.SmartServiceInstance, err error, code int) {
+	return this.updateInstanceInfo(token, id, element, &expectedUpdatedAt)
+}
+
+func (this *Controller) updateInstanceInfo(token auth.Token, id string, element model.SmartServiceInstanceInfo, expectedUpdatedAt *int64) (result model.SmartServiceInstance, err error, code int) {
 	access, err, code := this.permissions.CheckPermission(token.Token, this.config.SmartServiceInstancePermissionsTopic, id, client.Write)
 	if err != nil {
 		return result, err, code
@@ -176,12 +261,27 @@ func (this *Controller) UpdateInstanceInfo(token auth.Token, id string, element
 	if err != nil {
 		return result, err, code
 	}
+	if expectedUpdatedAt != nil && result.UpdatedAt != *expectedUpdatedAt {
+		return result, fmt.Errorf("instance has been changed since %v (current updated_at %v)", *expectedUpdatedAt, result.UpdatedAt), http.StatusPreconditionFailed
+	}
+	previousUpdatedAt := result.UpdatedAt
 	result.SmartServiceInstanceInfo = element
-	result.UpdatedAt = time.Now().Unix()
-	err, code = this.db.SetInstance(result)
+	result.UpdatedAt = nextUpdatedAt(previousUpdatedAt)
+	if expectedUpdatedAt != nil {
+		err, code = this.db.SetInstanceIfUpdatedAt(result, previousUpdatedAt)
+	} else {
+		err, code = this.db.SetInstance(result)
+	}
 	if err != nil {
 		return result, err, code
 	}
+	this.addInstanceEvent(model.SmartServiceInstanceEvent{
+		InstanceId:  result.Id,
+		Type:        model.InstanceEventInfoUpdated,
+		Actor:       userActor(token),
+		Name:        element.Name,
+		Description: element.Description,
+	})
 	arr := []model.SmartServiceInstance{result}
 	err, code = this.fillPermissions(token, arr)
 	if err != nil {
@@ -213,33 +313,47 @@ func (this *Controller) RedeployInstance(token auth.Token, id string, parameters
 	if !access {
 		return result, errors.New("missing release access"), http.StatusForbidden
 	}
-	err, code = this.DeleteInstance(token, id, false)
+	targetReleaseId := releaseId
+	if targetReleaseId == "" {
+		targetReleaseId = result.ReleaseId
+	}
+	release, err, code := this.GetExtendedRelease(token, targetReleaseId)
+	if err != nil {
+		return result, err, code
+	}
+	err, code = checkReleaseUsable(release.SmartServiceRelease)
+	if err != nil {
+		return result, err, code
+	}
+	err, code = this.validateInstanceParameters(token, release.ParsedInfo.ParameterDescriptions, parameters)
+	if err != nil {
+		return result, err, code
+	}
+	oldReleaseId := result.ReleaseId
+	oldParameters := result.Parameters
+	err, code = this.deleteInstance(token, id, false)
 	if err != nil {
 		return result, err, code
 	}
 	result.Ready = false
 	result.Deleting = false
 	result.Error = ""
+	result.RunningMaintenanceIds = nil
+	result.State = "" //the instance is stored again and starts a new lifecycle
+	err = result.SetState(model.InstanceStateCreating)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
 	result.Parameters = parameters
-	result.UpdatedAt = time.Now().Unix()
+	result.UpdatedAt = nextUpdatedAt(result.UpdatedAt)
 
-	var release model.SmartServiceReleaseExtended
 	if releaseId != "" {
-		release, err, code = this.GetExtendedRelease(token, releaseId)
-		if err != nil {
-			return result, err, code
-		}
 		result.ReleaseId = release.Id
 		if result.NewReleaseId == release.Id {
 			result.NewReleaseId = ""
 		}
 		result.DesignId = release.DesignId
 		result.NewReleaseId = release.NewReleaseId
-	} else {
-		release, err, code = this.GetExtendedRelease(token, result.ReleaseId)
-		if err != nil {
-			return result, err, code
-		}
 	}
 
 	paramListWithoutAutoSelect := result.Parameters
@@ -292,8 +406,24 @@ func (this *Controller) RedeployInstance(token auth.Token, id string, parameters
 	if err != nil {
 		this.config.GetLogger().Error("error in RedeployInstance", "error", err)
 		result.Error = err.Error()
+		err2 := this.updateInstanceState(&result, model.InstanceStateError)
+		if err2 != nil {
+			this.config.GetLogger().Error("error in RedeployInstance", "error", err2, "stack", string(debug.Stack()))
+		}
 		return result, err, http.StatusInternalServerError
 	}
+	err = this.updateInstanceState(&result, model.InstanceStateStarting)
+	if err != nil {
+		this.config.GetLogger().Error("error in RedeployInstance", "error", err, "stack", string(debug.Stack()))
+	}
+	this.addInstanceEvent(model.SmartServiceInstanceEvent{
+		InstanceId:          result.Id,
+		Type:                model.InstanceEventRedeployed,
+		Actor:               userActor(token),
+		OldReleaseId:        oldReleaseId,
+		NewReleaseId:        result.ReleaseId,
+		ChangedParameterIds: getChangedParameterIds(oldParameters, parameters),
+	})
 
 	arr := []model.SmartServiceInstance{result}
 	err, code = this.fillPermissions(token, arr)
@@ -307,6 +437,11 @@ func (this *Controller) RedeployInstance(token auth.Token, id string, parameters
 }
 
 func (this *Controller) ListInstances(token auth.Token, query model.InstanceQueryOptions) (result []model.SmartServiceInstance, total int64, err error, code int) {
+	for _, state := range query.States {
+		if !slices.Contains(model.InstanceStates, state) {
+			return result, total, fmt.Errorf("unknown instance state %v", state), http.StatusBadRequest
+		}
+	}
 	listOptions := client.ListOptions{}
 	if len(query.IDs) > 0 {
 		listOptions.Ids = query.IDs
@@ -319,6 +454,11 @@ func (this *Controller) ListInstances(token auth.Token, query model.InstanceQuer
 		return result, 0, nil, http.StatusOK
 	}
 	query.IDs = accessibleIds
+	if query.Test == nil {
+		hideTestInstances := false
+		query.Test = &hideTestInstances
+	}
+	query.UserId = token.GetUserId()
 	result, total, err, code = this.db.ListInstances("", query)
 	if err != nil {
 		return
@@ -357,6 +497,20 @@ func (this *Controller) GetInstance(token auth.Token, id string) (result model.S
 }
 
 func (this *Controller) DeleteInstance(token auth.Token, id string, ignoreModuleDeleteError bool) (error, int) {
+	err, code := this.deleteInstance(token, id, ignoreModuleDeleteError)
+	if err != nil {
+		return err, code
+	}
+	this.addInstanceEvent(model.SmartServiceInstanceEvent{
+		InstanceId: id,
+		Type:       model.InstanceEventDeleted,
+		Actor:      userActor(token),
+	})
+	return nil, code
+}
+
+// deleteInstance is used by DeleteInstance and RedeployInstance and does not add an event to the instance history
+func (this *Controller) deleteInstance(token auth.Token, id string, ignoreModuleDeleteError bool) (error, int) {
 	access, err, code := this.permissions.CheckPermission(token.Token, this.config.SmartServiceInstancePermissionsTopic, id, client.Administrate, client.Write)
 	if err != nil {
 		return err, code
@@ -375,11 +529,12 @@ func (this *Controller) DeleteInstance(token auth.Token, id string, ignoreModule
 
 	//mark instance as transitioning while other delete work is done
 	current.Deleting = true
-	current.UpdatedAt = time.Now().Unix()
+	current.UpdatedAt = nextUpdatedAt(current.UpdatedAt)
 	err, code = this.db.SetInstance(current)
 	if err != nil {
 		return err, code
 	}
+	this.updateInstanceStateOrLog(&current, model.InstanceStateDeleting)
 
 	//stop running instances
 	err = this.camunda.StopInstance(id)
@@ -411,8 +566,6 @@ func (this *Controller) handleReadyAndErrorFields(list []model.SmartServiceInsta
 	return list
 }
 
-const ErrMissingCamundaProcessInstance = "missing camunda process instance"
-
 func (this *Controller) handleReadyAndErrorField(instance model.SmartServiceInstance) model.SmartServiceInstance {
 	if instance.Ready {
 		return instance
@@ -424,16 +577,19 @@ func (this *Controller) handleReadyAndErrorField(instance model.SmartServiceInst
 	}
 	if missing {
 		instance.Ready = false
-		instance.Error = ErrMissingCamundaProcessInstance
+		instance.Error = model.ErrMissingCamundaProcessInstance
 		err, _ = this.db.SetInstance(instance)
 		if err != nil {
 			this.config.GetLogger().Error("error in handleReadyAndErrorField", "error", err, "stack", string(debug.Stack()))
 			return instance
 		}
+		if instance.State != model.InstanceStateDeleting {
+			this.updateInstanceStateOrLog(&instance, model.InstanceStateStopped)
+		}
 	}
 	if finished {
 		instance.Ready = true
-		if instance.Error == ErrMissingCamundaProcessInstance {
+		if instance.Error == model.ErrMissingCamundaProcessInstance {
 			instance.Error = ""
 		}
 		err, _ := this.db.SetInstance(instance)
@@ -441,6 +597,9 @@ func (this *Controller) handleReadyAndErrorField(instance model.SmartServiceInst
 			this.config.GetLogger().Error("error in handleReadyAndErrorField", "error", err, "stack", string(debug.Stack()))
 			return instance
 		}
+		if instance.Error == "" && instance.State != model.InstanceStateDeleting {
+			this.updateInstanceStateOrLog(&instance, model.InstanceStateReady)
+		}
 	}
 	return instance
 }
@@ -477,6 +636,12 @@ func (this *Controller) removeFinishedMaintenanceIds(instance model.SmartService
 			return instance
 		}
 	}
+	if len(newMaintenanceIds) == 0 && instance.State == model.InstanceStateMaintenance {
+		err := this.updateInstanceState(&instance, model.InstanceStateReady)
+		if err != nil {
+			this.config.GetLogger().Error("error in removeFinishedMaintenanceIds", "error", err, "stack", string(debug.Stack()))
+		}
+	}
 	return instance
 }
 
@@ -488,10 +653,10 @@ func (this *Controller) SetInstanceError(token auth.Token, instanceId string, er
 	if !access {
 		return errors.New("missing instance write access"), http.StatusForbidden
 	}
-	return this.setInstanceError(instanceId, errMsg)
+	return this.setInstanceError(instanceId, errMsg, userActor(token))
 }
 
-func (this *Controller) setInstanceError(instanceId string, errMsg string) (error, int) {
+func (this *Controller) setInstanceError(instanceId string, errMsg string, actor model.InstanceEventActor) (error, int) {
 	if instanceId == "" {
 		return errors.New("missing instance id"), http.StatusBadRequest
 	}
@@ -510,6 +675,16 @@ func (this *Controller) setInstanceError(instanceId string, errMsg string) (erro
 	if err != nil {
 		return err, http.StatusInternalServerError
 	}
+	err = this.updateInstanceState(&instance, model.InstanceStateError)
+	if err != nil {
+		this.config.GetLogger().Warn("unable to update instance state in setInstanceError", "instanceId", instanceId, "error", err)
+	}
+	this.addInstanceEvent(model.SmartServiceInstanceEvent{
+		InstanceId: instanceId,
+		Type:       model.InstanceEventError,
+		Actor:      actor,
+		Error:      errMsg,
+	})
 	return nil, http.StatusOK
 }
 
@@ -521,7 +696,7 @@ func (this *Controller) SetInstanceErrorByProcessInstanceId(processInstanceId st
 	if err != nil {
 		return err, code
 	}
-	return this.setInstanceError(businessKey, errMsg)
+	return this.setInstanceError(businessKey, errMsg, processInstanceActor(processInstanceId))
 }
 
 func (this *Controller) GetInstanceByProcessInstanceId(processInstanceId string) (result model.SmartServiceInstance, err error, code int) {
@@ -662,3 +837,9 @@ func (this *Controller) fillPermissions(token auth.Token, instances []model.Smar
 func createRef(id string) string {
 	return "{{." + id + "}}"
 }
+
+// nextUpdatedAt returns the current unix timestamp but at least previous+1,
+// so that updated_at changes on every update and may be used as ETag
+func nextUpdatedAt(previous int64) int64 {
+	return max(time.Now().Unix(), previous+1)
+}
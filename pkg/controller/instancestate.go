@@ -0,0 +1,58 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+)
+
+// updateInstanceState validates the state transition and stores the new state of the instance;
+// the stored state is not changed if it has been changed concurrently
+func (this *Controller) updateInstanceState(instance *model.SmartServiceInstance, state string) error {
+	previous := instance.State
+	if previous == state {
+		return nil
+	}
+	err := instance.SetState(state)
+	if err != nil {
+		return err
+	}
+	return this.db.SetInstanceState(instance.Id, previous, instance.State, instance.StateChangedAt)
+}
+
+// updateInstanceStateOrLog is used after the instance has been stored with SetInstance, which does not change the state;
+// invalid transitions are logged and ignored
+func (this *Controller) updateInstanceStateOrLog(instance *model.SmartServiceInstance, state string) {
+	err := this.updateInstanceState(instance, state)
+	if err != nil {
+		this.config.GetLogger().Warn("ignore instance state change", "instanceId", instance.Id, "error", err)
+	}
+}
+
+// migrateInstanceStates stores the state of instances created before the state field existed
+func migrateInstanceStates(db Database, instances []model.SmartServiceInstance) error {
+	for _, instance := range instances {
+		if instance.State != "" {
+			continue
+		}
+		err := db.SetInstanceState(instance.Id, "", instance.GetState(), instance.UpdatedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
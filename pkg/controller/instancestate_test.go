@@ -0,0 +1,91 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/database/mongo"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/docker"
+)
+
+func TestSetInstanceKeepsState(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config, err := configuration.Load("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	host, port, err := docker.MongoDB(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	config.MongoUrl = "mongodb://" + host + ":" + port
+
+	db, err := mongo.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	instance := model.SmartServiceInstance{Id: "instance", UserId: "user"}
+	instance.Name = "name"
+	err = instance.SetState(model.InstanceStateCreating)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	err, _ = db.SetInstance(instance)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	err = db.SetInstanceState(instance.Id, model.InstanceStateCreating, model.InstanceStateStarting, 42)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	//stale in-memory state
+	instance.Name = "updated"
+	err, _ = db.SetInstance(instance)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	result, err, _ := db.GetInstance(instance.Id, "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if result.Name != "updated" || result.State != model.InstanceStateStarting || result.StateChangedAt != 42 {
+		t.Errorf("%#v", result)
+	}
+}
@@ -105,10 +105,31 @@ func (this *Controller) StartMaintenanceProcedure(token auth.Token, instanceId s
 	if err != nil {
 		return err, http.StatusInternalServerError
 	}
+	previousState := instance.GetState()
+	err = this.updateInstanceState(&instance, model.InstanceStateMaintenance)
+	if err != nil {
+		this.config.GetLogger().Warn("unable to update instance state in StartMaintenanceProcedure", "instanceId", instanceId, "error", err)
+	}
 
 	err = this.camunda.StartMaintenance(instance.ReleaseId, procedure, maintenanceId, paramListWithAutoSelect)
 	if err != nil {
+		//the procedure has not been started; the instance would otherwise stay in maintenance
+		temperr := this.db.RemoveFromRunningMaintenanceIds(instanceId, []string{maintenanceId})
+		if temperr != nil {
+			this.config.GetLogger().Warn("unable to remove maintenance id after failed start", "instanceId", instanceId, "maintenanceId", maintenanceId, "error", temperr)
+		}
+		temperr = this.updateInstanceState(&instance, previousState)
+		if temperr != nil {
+			this.config.GetLogger().Warn("unable to reset instance state after failed maintenance start", "instanceId", instanceId, "error", temperr)
+		}
 		return err, http.StatusInternalServerError
 	}
+	this.addInstanceEvent(model.SmartServiceInstanceEvent{
+		InstanceId:    instanceId,
+		Type:          model.InstanceEventMaintenanceStarted,
+		Actor:         userActor(token),
+		MaintenanceId: maintenanceId,
+		PublicEventId: publicEventId,
+	})
 	return nil, http.StatusOK
 }
@@ -38,7 +38,17 @@ func (this *Controller) AddModule(token auth.Token, instanceId string, module mo
 	if !access {
 		return result, errors.New("missing instance write access"), http.StatusForbidden
 	}
-	return this.addModule(instanceId, module, uuid.NewString())
+	result, err, code = this.addModule(instanceId, module, uuid.NewString())
+	if err != nil {
+		return result, err, code
+	}
+	this.addInstanceEvent(model.SmartServiceInstanceEvent{
+		InstanceId: instanceId,
+		Type:       model.InstanceEventModulesAdded,
+		Actor:      userActor(token),
+		ModuleIds:  []string{result.Id},
+	})
+	return result, nil, code
 }
 
 func (this *Controller) AddModuleForProcessInstance(processInstanceId string, module model.SmartServiceModuleInit) (result model.SmartServiceModule, err error, code int) {
@@ -56,7 +66,17 @@ func (this *Controller) SetModuleForProcessInstance(processInstanceId string, mo
 	if err != nil {
 		return result, err, code
 	}
-	return this.addModule(businessKey, module, moduleId)
+	result, err, code = this.addModule(businessKey, module, moduleId)
+	if err != nil {
+		return result, err, code
+	}
+	this.addInstanceEvent(model.SmartServiceInstanceEvent{
+		InstanceId: businessKey,
+		Type:       model.InstanceEventModulesAdded,
+		Actor:      processInstanceActor(processInstanceId),
+		ModuleIds:  []string{result.Id},
+	})
+	return result, nil, code
 }
 
 func (this *Controller) addModule(instanceId string, module model.SmartServiceModuleInit, moduleId string) (result model.SmartServiceModule, err error, code int) {
@@ -215,7 +235,17 @@ func (this *Controller) DeleteModule(token auth.Token, id string, ignoreModuleDe
 		return errors.New("missing instance administrate access"), http.StatusForbidden
 	}
 
-	return this.deleteModule(module, ignoreModuleDeleteError)
+	err, code = this.deleteModule(module, ignoreModuleDeleteError)
+	if err != nil {
+		return err, code
+	}
+	this.addInstanceEvent(model.SmartServiceInstanceEvent{
+		InstanceId: module.InstanceId,
+		Type:       model.InstanceEventModuleDeleted,
+		Actor:      userActor(token),
+		ModuleIds:  []string{module.Id},
+	})
+	return nil, code
 }
 
 func (this *Controller) deleteModule(module model.SmartServiceModule, ignoreModuleDeleteError bool) (err error, code int) {
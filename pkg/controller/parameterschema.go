@@ -0,0 +1,126 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+)
+
+// GetReleaseParameterSchema returns the start parameters of the release as JSON Schema.
+// options are resolved for the requesting user, like in GetReleaseParameter.
+func (this *Controller) GetReleaseParameterSchema(token auth.Token, id string) (result model.ParameterJsonSchema, err error, code int) {
+	access, err, _ := this.permissions.CheckPermission(token.Jwt(), this.config.SmartServiceReleasePermissionsTopic, id, client.Execute)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !access {
+		return result, errors.New("access denied"), http.StatusForbidden
+	}
+	release, err, code := this.db.GetRelease(id, false)
+	if err != nil {
+		return result, err, code
+	}
+	parameters, err, code := this.parameterDescriptionsToSmartServiceExtendedParameter(token, release.ParsedInfo.ParameterDescriptions)
+	if err != nil {
+		return result, err, code
+	}
+	return ParametersToJsonSchema(release.Name, release.Description, parameters), nil, http.StatusOK
+}
+
+// GetMaintenanceProcedureParameterSchemaOfInstance returns the parameters of the maintenance procedure as JSON Schema.
+func (this *Controller) GetMaintenanceProcedureParameterSchemaOfInstance(token auth.Token, instanceId string, publicEventId string) (result model.ParameterJsonSchema, err error, code int) {
+	procedure, _, _, err, code := this.GetMaintenanceProcedureOfInstance(token, instanceId, publicEventId)
+	if err != nil {
+		return result, err, code
+	}
+	parameters, err, code := this.parameterDescriptionsToSmartServiceExtendedParameter(token, procedure.ParameterDescriptions)
+	if err != nil {
+		return result, err, code
+	}
+	return ParametersToJsonSchema(procedure.PublicEventId, "", parameters), nil, http.StatusOK
+}
+
+// ParametersToJsonSchema describes the parameters as JSON Schema object, with the parameter ids as property names.
+// multiple parameters are arrays, optional parameters are not required and options are used as enum.
+func ParametersToJsonSchema(title string, description string, parameters []model.SmartServiceExtendedParameter) (result model.ParameterJsonSchema) {
+	additionalProperties := false
+	result = model.ParameterJsonSchema{
+		Schema:               model.JsonSchemaDraft,
+		Title:                title,
+		Description:          description,
+		Type:                 "object",
+		Properties:           map[string]model.ParameterJsonSchema{},
+		Required:             []string{},
+		AdditionalProperties: &additionalProperties,
+	}
+	for _, param := range parameters {
+		order := param.Order
+		property := model.ParameterJsonSchema{
+			Title:       param.Label,
+			Description: param.Description,
+			Order:       &order,
+		}
+		value := model.ParameterJsonSchema{
+			Type: getJsonSchemaType(param.Type),
+		}
+		for _, option := range param.Options {
+			value.Enum = append(value.Enum, option.Value)
+			value.EnumLabels = append(value.EnumLabels, option.Label)
+		}
+		if param.Multiple {
+			property.Type = "array"
+			property.Items = &value
+			if param.DefaultValue != nil {
+				if list, ok := param.DefaultValue.([]interface{}); ok {
+					property.Default = list
+				} else {
+					property.Default = []interface{}{param.DefaultValue}
+				}
+			}
+		} else {
+			property.Type = value.Type
+			property.Enum = value.Enum
+			property.EnumLabels = value.EnumLabels
+			property.Default = param.DefaultValue
+		}
+		result.Properties[param.Id] = property
+		if !param.Optional {
+			result.Required = append(result.Required, param.Id)
+		}
+	}
+	return result
+}
+
+func getJsonSchemaType(t model.Type) string {
+	switch t {
+	case model.Boolean:
+		return "boolean"
+	case model.String:
+		return "string"
+	case model.Integer:
+		return "integer"
+	case model.Float:
+		return "number"
+	default:
+		return ""
+	}
+}
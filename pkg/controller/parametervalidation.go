@@ -0,0 +1,229 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+)
+
+// validateInstanceParameters resolves the options of all set parameters for the user and checks the parameters with ValidateInstanceParameters
+func (this *Controller) validateInstanceParameters(token auth.Token, descriptions []model.ParameterDescription, parameters []model.SmartServiceParameter) (error, int) {
+	isSet := map[string]bool{}
+	for _, param := range parameters {
+		if param.Value != nil {
+			isSet[param.Id] = true
+		}
+	}
+	options := map[string][]model.Option{}
+	for _, desc := range descriptions {
+		if desc.AutoSelectAll || !isSet[desc.Id] || (desc.Options == nil && desc.IotDescription == nil) {
+			continue
+		}
+		paramOptions, err, code := this.getParamOptions(token, desc)
+		if err != nil {
+			return err, code
+		}
+		options[desc.Id] = paramOptions
+	}
+	err := ValidateInstanceParameters(descriptions, parameters, options)
+	if err != nil {
+		return err, http.StatusBadRequest
+	}
+	return nil, http.StatusOK
+}
+
+// ValidateInstanceParameters checks instance parameters against the parameter descriptions of a release.
+// options contains the valid options of parameters with static or iot options, indexed by parameter id;
+// parameters without entry in options are not checked against their options.
+// all found problems are returned as joined error.
+func ValidateInstanceParameters(descriptions []model.ParameterDescription, parameters []model.SmartServiceParameter, options map[string][]model.Option) error {
+	errList := []error{}
+	descIndex := map[string]model.ParameterDescription{}
+	for _, desc := range descriptions {
+		descIndex[desc.Id] = desc
+	}
+	values := map[string]interface{}{}
+	for _, param := range parameters {
+		desc, known := descIndex[param.Id]
+		if !known {
+			errList = append(errList, fmt.Errorf("unknown parameter %v", param.Id))
+			continue
+		}
+		if _, duplicate := values[param.Id]; duplicate {
+			errList = append(errList, fmt.Errorf("duplicate parameter %v", param.Id))
+			continue
+		}
+		if desc.AutoSelectAll {
+			errList = append(errList, fmt.Errorf("parameter %v is selected automatically and may not be set", param.Id))
+			continue
+		}
+		values[param.Id] = param.Value
+	}
+
+	selectedEntities := map[string][]string{}
+	for _, desc := range descriptions {
+		if desc.AutoSelectAll {
+			continue
+		}
+		value := values[desc.Id]
+		if value == nil || (desc.Optional && value == "") {
+			if !desc.Optional && desc.DefaultValue == nil {
+				errList = append(errList, fmt.Errorf("missing parameter %v", desc.Id))
+			}
+			continue
+		}
+		elements := []interface{}{value}
+		list, isList := value.([]interface{})
+		if desc.Multiple {
+			if !isList {
+				errList = append(errList, fmt.Errorf("parameter %v expects a list of values", desc.Id))
+				continue
+			}
+			elements = list
+		} else if isList {
+			errList = append(errList, fmt.Errorf("parameter %v expects a single value", desc.Id))
+			continue
+		}
+		paramOptions, hasOptions := options[desc.Id]
+		for _, element := range elements {
+			err := checkParameterType(desc.Type, element)
+			if err != nil {
+				errList = append(errList, fmt.Errorf("parameter %v: %w", desc.Id, err))
+				continue
+			}
+			if !hasOptions {
+				continue
+			}
+			option, found := findParameterOption(paramOptions, element)
+			if !found {
+				errList = append(errList, fmt.Errorf("parameter %v: value %v is not a valid option", desc.Id, element))
+				continue
+			}
+			if option.EntityId != "" {
+				selectedEntities[desc.Id] = append(selectedEntities[desc.Id], option.EntityId)
+			}
+		}
+	}
+
+	for _, desc := range descriptions {
+		if desc.IotDescription == nil || desc.IotDescription.NeedsSameEntityIdInParameter == "" {
+			continue
+		}
+		reference := desc.IotDescription.NeedsSameEntityIdInParameter
+		if len(selectedEntities[desc.Id]) == 0 || len(selectedEntities[reference]) == 0 {
+			continue
+		}
+		for _, entityId := range selectedEntities[desc.Id] {
+			if !slices.Contains(selectedEntities[reference], entityId) {
+				errList = append(errList, fmt.Errorf("parameter %v: selected entity %v is not selected in parameter %v", desc.Id, entityId, reference))
+			}
+		}
+	}
+	return errors.Join(errList...)
+}
+
+// checkParameterType checks a single value against the camunda form field type; unknown types are not checked
+func checkParameterType(fieldType string, value interface{}) error {
+	switch fieldType {
+	case "string":
+		switch value.(type) {
+		case string, map[string]interface{}:
+			return nil //objects are json encoded for camunda
+		}
+		return fmt.Errorf("expected string, got %T", value)
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("expected boolean, got %T", value)
+		}
+		return nil
+	case "long":
+		number, ok := parameterNumber(value)
+		if !ok {
+			return fmt.Errorf("expected integer, got %T", value)
+		}
+		if number != math.Trunc(number) {
+			return fmt.Errorf("expected integer, got %v", number)
+		}
+		return nil
+	case "number":
+		if _, ok := parameterNumber(value); !ok {
+			return fmt.Errorf("expected number, got %T", value)
+		}
+		return nil
+	}
+	return nil
+}
+
+func parameterNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func findParameterOption(options []model.Option, value interface{}) (model.Option, bool) {
+	normalized := normalizeParameterValue(value)
+	for _, option := range options {
+		if reflect.DeepEqual(normalizeParameterValue(option.Value), normalized) {
+			return option, true
+		}
+	}
+	return model.Option{}, false
+}
+
+// normalizeParameterValue makes values comparable independent of their go number types;
+// iot option values are compared without their label, which may change with the device name
+func normalizeParameterValue(value interface{}) interface{} {
+	if str, ok := value.(string); ok && strings.HasPrefix(strings.TrimSpace(str), "{") {
+		iotOption := model.IotOption{}
+		err := json.Unmarshal([]byte(str), &iotOption)
+		if err == nil && (iotOption.DeviceSelection != nil || iotOption.DeviceGroupSelection != nil || iotOption.ImportSelection != nil || iotOption.GenericEventSource != nil) {
+			iotOption.Label = ""
+			return iotOption
+		}
+	}
+	temp, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var result interface{}
+	err = json.Unmarshal(temp, &result)
+	if err != nil {
+		return value
+	}
+	return result
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/notification"
+)
+
+// isReleasePublisher returns true if releases of the user may be published without approval
+func (this *Controller) isReleasePublisher(token auth.Token) bool {
+	return this.config.ReleasePublisherRole == "" || token.IsAdmin() || token.HasRole(this.config.ReleasePublisherRole)
+}
+
+func (this *Controller) isReleaseReviewer(token auth.Token) bool {
+	return token.IsAdmin() || (this.config.ReleaseReviewerRole != "" && token.HasRole(this.config.ReleaseReviewerRole))
+}
+
+// ListPendingReleases lists all releases waiting for approval, oldest first
+func (this *Controller) ListPendingReleases(token auth.Token) (result []model.SmartServiceReleaseExtended, err error, code int) {
+	if !this.isReleaseReviewer(token) {
+		return result, errors.New("only reviewers may list pending releases"), http.StatusForbidden
+	}
+	result, err = this.db.GetReleasesByLifecycleState(model.ReleaseLifecycleStatePendingApproval)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if result == nil {
+		result = []model.SmartServiceReleaseExtended{}
+	}
+	return result, nil, http.StatusOK
+}
+
+// ApproveRelease deploys a release waiting for approval and publishes it.
+// reviewers may not approve their own releases.
+func (this *Controller) ApproveRelease(token auth.Token, id string, review model.SmartServiceReleaseReview) (result model.SmartServiceRelease, err error, code int) {
+	release, err, code := this.getReviewableRelease(token, id)
+	if err != nil {
+		return result, err, code
+	}
+
+	this.cleanupMux.Lock()
+	defer this.cleanupMux.Unlock()
+
+	deployed, err := this.camunda.IsReleaseDeployed(release.Id)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !deployed {
+		err, _ = this.camunda.DeployRelease(release.Creator, release)
+		if err != nil {
+			return result, err, http.StatusInternalServerError
+		}
+	}
+
+	oldReleases, err := this.getOldReleases(release)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	//a newer release may have been published while this release was waiting for approval
+	for _, other := range oldReleases {
+		if other.IsReleased() && other.CreatedAt > release.CreatedAt && other.NewReleaseId == "" {
+			release.NewReleaseId = other.Id
+		}
+	}
+
+	release.LifecycleState = model.ReleaseLifecycleStatePublished
+	release.Approval = &model.SmartServiceReleaseApproval{
+		Approved:   true,
+		ReviewerId: token.GetUserId(),
+		Comment:    review.Comment,
+		ReviewedAt: time.Now().Unix(),
+	}
+	err, code = this.db.SetRelease(release, false)
+	if err != nil {
+		return result, err, code
+	}
+	if release.NewReleaseId == "" {
+		err = this.replaceOldReleases(release, oldReleases)
+		if err != nil {
+			return result, err, http.StatusInternalServerError
+		}
+	}
+	this.notifyReleaseCreatorOfReview(release)
+	return release.SmartServiceRelease, nil, http.StatusOK
+}
+
+// RejectRelease rejects a release waiting for approval; the comment is required.
+func (this *Controller) RejectRelease(token auth.Token, id string, review model.SmartServiceReleaseReview) (result model.SmartServiceRelease, err error, code int) {
+	if review.Comment == "" {
+		return result, errors.New("missing comment"), http.StatusBadRequest
+	}
+	release, err, code := this.getReviewableRelease(token, id)
+	if err != nil {
+		return result, err, code
+	}
+	release.LifecycleState = model.ReleaseLifecycleStateRejected
+	release.Approval = &model.SmartServiceReleaseApproval{
+		Approved:   false,
+		ReviewerId: token.GetUserId(),
+		Comment:    review.Comment,
+		ReviewedAt: time.Now().Unix(),
+	}
+	err, code = this.db.SetRelease(release, false)
+	if err != nil {
+		return result, err, code
+	}
+	this.notifyReleaseCreatorOfReview(release)
+	return release.SmartServiceRelease, nil, http.StatusOK
+}
+
+func (this *Controller) getReviewableRelease(token auth.Token, id string) (release model.SmartServiceReleaseExtended, err error, code int) {
+	if !this.isReleaseReviewer(token) {
+		return release, errors.New("only reviewers may approve or reject releases"), http.StatusForbidden
+	}
+	release, err, code = this.db.GetRelease(id, false)
+	if err != nil {
+		return release, err, code
+	}
+	if release.GetLifecycleState() != model.ReleaseLifecycleStatePendingApproval {
+		return release, fmt.Errorf("release is not waiting for approval (lifecycle_state %v)", release.GetLifecycleState()), http.StatusBadRequest
+	}
+	if release.Creator == token.GetUserId() {
+		return release, errors.New("releases may not be reviewed by their creator"), http.StatusForbidden
+	}
+	return release, nil, http.StatusOK
+}
+
+func (this *Controller) notifyReleaseCreatorOfReview(release model.SmartServiceReleaseExtended) {
+	title := "Smart-Service-Release Approved"
+	consequence := "The release is published and may be used to create instances."
+	if release.Approval == nil || !release.Approval.Approved {
+		title = "Smart-Service-Release Rejected"
+		consequence = "The release may not be used and can be deleted."
+	}
+	message := fmt.Sprintf("%s \nRelease-Name: %s \nRelease-ID: %s \n%s", title, release.Name, release.Id, consequence)
+	if release.Approval != nil && release.Approval.Comment != "" {
+		message = message + " \nComment: " + release.Approval.Comment
+	}
+	_ = notification.Send(this.config.NotificationUrl, notification.Message{
+		UserId:  release.Creator,
+		Title:   title,
+		Message: message,
+	}, this.config.GetLogger())
+}
+
+// notifyReviewersOfPendingRelease informs the configured reviewers; the reviewer role itself can not be resolved to users
+func (this *Controller) notifyReviewersOfPendingRelease(release model.SmartServiceReleaseExtended) {
+	title := "Smart-Service-Release Waiting For Approval"
+	message := fmt.Sprintf("%s \nRelease-Name: %s \nRelease-ID: %s \nCreator: %s", title, release.Name, release.Id, release.Creator)
+	for _, userId := range this.config.ReleaseReviewerNotificationUserIds {
+		_ = notification.Send(this.config.NotificationUrl, notification.Message{
+			UserId:  userId,
+			Title:   title,
+			Message: message,
+		}, this.config.GetLogger())
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"strings"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/beevik/etree"
+)
+
+// GetReleaseFacets counts the releases matching query per category and tag; limit, offset and sort are ignored
+func (this *Controller) GetReleaseFacets(token auth.Token, query model.ReleaseQueryOptions) (result model.ReleaseFacets, err error, code int) {
+	options, err, code := this.getListReleasesOptions(token, query)
+	if err != nil {
+		return result, err, code
+	}
+	result, err = this.db.GetReleaseFacets(options)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return result, nil, http.StatusOK
+}
+
+// setReleaseCatalogDefaults uses the senergy:category, senergy:tags and senergy:icon attributes of the bpmn
+// process/collaboration for catalog fields not set by the user
+func (this *Controller) setReleaseCatalogDefaults(element *model.SmartServiceRelease, bpmn string) error {
+	category, tags, icon, err := this.getProcessModelCatalogInfo(bpmn)
+	if err != nil {
+		return err
+	}
+	element.Category = strings.TrimSpace(element.Category)
+	if element.Category == "" {
+		element.Category = category
+	}
+	element.Tags = normalizeReleaseTags(element.Tags)
+	if len(element.Tags) == 0 {
+		element.Tags = tags
+	}
+	element.Icon = strings.TrimSpace(element.Icon)
+	if element.Icon == "" {
+		element.Icon = icon
+	}
+	return nil
+}
+
+func (this *Controller) getProcessModelCatalogInfo(bpmn string) (category string, tags []string, icon string, err error) {
+	defer func() {
+		if r := recover(); r != nil && err == nil {
+			this.config.GetLogger().Error("error in getProcessModelCatalogInfo", "error", r, "stack", string(debug.Stack()))
+			err = errors.New(fmt.Sprint("Recovered Error: ", r))
+		}
+	}()
+	doc := etree.NewDocument()
+	err = doc.ReadFromString(bpmn)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	var element *etree.Element
+	if len(doc.FindElements("//bpmn:collaboration")) > 0 {
+		element = doc.FindElement("//bpmn:collaboration")
+	} else {
+		element = doc.FindElement("//bpmn:process")
+	}
+	category = strings.TrimSpace(element.SelectAttrValue("senergy:category", ""))
+	tags = normalizeReleaseTags(strings.Split(element.SelectAttrValue("senergy:tags", ""), ","))
+	icon = strings.TrimSpace(element.SelectAttrValue("senergy:icon", ""))
+	return category, tags, icon, nil
+}
+
+// normalizeReleaseTags trims the tags and removes empty and duplicate entries; returns nil if no tag remains
+func normalizeReleaseTags(tags []string) (result []string) {
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag != "" && !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
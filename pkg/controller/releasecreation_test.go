@@ -0,0 +1,93 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/database/mongo"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/docker"
+)
+
+func TestFailInterruptedReleaseCreations(t *testing.T) {
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config, err := configuration.Load("../../config.json")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	host, port, err := docker.MongoDB(ctx, wg)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	config.MongoUrl = "mongodb://" + host + ":" + port
+
+	db, err := mongo.New(config)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, creation := range []model.SmartServiceReleaseCreation{
+		{ReleaseId: "pending", UserId: "user", Status: model.ReleaseCreationStatusPending},
+		{ReleaseId: "deploying", UserId: "user", Status: model.ReleaseCreationStatusDeploying},
+		{ReleaseId: "ready", UserId: "user", Status: model.ReleaseCreationStatusReady},
+	} {
+		err, _ = db.SetReleaseCreation(creation)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	err = failInterruptedReleaseCreations(db)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, id := range []string{"pending", "deploying"} {
+		creation, err, _ := db.GetReleaseCreation(id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if creation.Status != model.ReleaseCreationStatusFailed || creation.Error == "" {
+			t.Errorf("%#v", creation)
+		}
+	}
+
+	creation, err, _ := db.GetReleaseCreation("ready")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if creation.Status != model.ReleaseCreationStatusReady || creation.Error != "" {
+		t.Errorf("%#v", creation)
+	}
+}
@@ -0,0 +1,252 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+)
+
+// parameter changes after which a value stored for the old release may no longer be valid
+var reenterParameterChanges = []string{"type", "multiple", "options", "iot_type_filter", "iot_criteria", "iot_entity_only", "iot_same_entity", "characteristic"}
+
+func (this *Controller) DiffReleases(token auth.Token, id string, otherId string) (result model.SmartServiceReleaseDiff, err error, code int) {
+	from, err, code := this.GetExtendedRelease(token, id)
+	if err != nil {
+		return result, err, code
+	}
+	to, err, code := this.GetExtendedRelease(token, otherId)
+	if err != nil {
+		return result, err, code
+	}
+	if from.DesignId != to.DesignId {
+		return result, errors.New("releases belong to different designs"), http.StatusBadRequest
+	}
+	result = DiffReleaseInfo(from.ParsedInfo, to.ParsedInfo)
+	result.ReleaseId = id
+	result.OtherReleaseId = otherId
+	return result, nil, http.StatusOK
+}
+
+// DiffReleaseInfo compares the parsed info of two releases.
+// parameters are matched by their id, maintenance procedures by their public event id and analytics modules by their flow id.
+func DiffReleaseInfo(from model.SmartServiceReleaseInfo, to model.SmartServiceReleaseInfo) (result model.SmartServiceReleaseDiff) {
+	result.Parameters = diffParameterDescriptions(from.ParameterDescriptions, to.ParameterDescriptions)
+	result.MaintenanceProcedures = diffMaintenanceProcedures(from.MaintenanceProcedures, to.MaintenanceProcedures)
+	result.Analytics = diffAnalytics(from.ModuleInfo.Analytics, to.ModuleInfo.Analytics)
+	result.ReenterParameterIds = []string{}
+	for _, param := range to.ParameterDescriptions {
+		index := slices.IndexFunc(from.ParameterDescriptions, func(old model.ParameterDescription) bool { return old.Id == param.Id })
+		if index < 0 {
+			if !param.Optional {
+				result.ReenterParameterIds = append(result.ReenterParameterIds, param.Id)
+			}
+			continue
+		}
+		old := from.ParameterDescriptions[index]
+		changes := getParameterDescriptionChanges(old, param)
+		if (old.Optional && !param.Optional) || slices.ContainsFunc(changes, func(change string) bool {
+			return slices.Contains(reenterParameterChanges, change)
+		}) {
+			result.ReenterParameterIds = append(result.ReenterParameterIds, param.Id)
+		}
+	}
+	slices.Sort(result.ReenterParameterIds)
+	return result
+}
+
+func diffParameterDescriptions(from []model.ParameterDescription, to []model.ParameterDescription) (result model.ParameterDiffSection) {
+	result = model.ParameterDiffSection{
+		Added:   []model.ParameterDiffEntry{},
+		Removed: []model.ParameterDiffEntry{},
+		Changed: []model.ParameterDiffEntry{},
+	}
+	toEntry := func(param model.ParameterDescription) model.ParameterDiffEntry {
+		return model.ParameterDiffEntry{Id: param.Id, Label: param.Label, Type: param.Type}
+	}
+	for _, fromParam := range from {
+		index := slices.IndexFunc(to, func(param model.ParameterDescription) bool { return param.Id == fromParam.Id })
+		if index < 0 {
+			result.Removed = append(result.Removed, toEntry(fromParam))
+			continue
+		}
+		if changes := getParameterDescriptionChanges(fromParam, to[index]); len(changes) > 0 {
+			entry := toEntry(to[index])
+			entry.Changes = changes
+			result.Changed = append(result.Changed, entry)
+		}
+	}
+	for _, toParam := range to {
+		if !slices.ContainsFunc(from, func(param model.ParameterDescription) bool { return param.Id == toParam.Id }) {
+			result.Added = append(result.Added, toEntry(toParam))
+		}
+	}
+	for _, list := range [][]model.ParameterDiffEntry{result.Added, result.Removed, result.Changed} {
+		slices.SortFunc(list, func(a, b model.ParameterDiffEntry) int {
+			return strings.Compare(a.Id, b.Id)
+		})
+	}
+	return result
+}
+
+func getParameterDescriptionChanges(from model.ParameterDescription, to model.ParameterDescription) (changes []string) {
+	fromIot := model.IotDescription{}
+	if from.IotDescription != nil {
+		fromIot = *from.IotDescription
+	}
+	toIot := model.IotDescription{}
+	if to.IotDescription != nil {
+		toIot = *to.IotDescription
+	}
+	fromCharacteristic := ""
+	if from.CharacteristicId != nil {
+		fromCharacteristic = *from.CharacteristicId
+	}
+	toCharacteristic := ""
+	if to.CharacteristicId != nil {
+		toCharacteristic = *to.CharacteristicId
+	}
+	fields := []struct {
+		name  string
+		equal bool
+	}{
+		{name: "label", equal: from.Label == to.Label},
+		{name: "description", equal: from.Description == to.Description},
+		{name: "type", equal: from.Type == to.Type},
+		{name: "default_value", equal: equalParameterValue(from.DefaultValue, to.DefaultValue)},
+		{name: "multiple", equal: from.Multiple == to.Multiple},
+		{name: "auto_select_all", equal: from.AutoSelectAll == to.AutoSelectAll},
+		{name: "options", equal: equalParameterValue(from.Options, to.Options)},
+		{name: "iot_type_filter", equal: equalParameterValue(fromIot.TypeFilter, toIot.TypeFilter)},
+		{name: "iot_criteria", equal: equalParameterValue(fromIot.Criteria, toIot.Criteria)},
+		{name: "iot_entity_only", equal: fromIot.EntityOnly == toIot.EntityOnly},
+		{name: "iot_same_entity", equal: fromIot.NeedsSameEntityIdInParameter == toIot.NeedsSameEntityIdInParameter},
+		{name: "characteristic", equal: fromCharacteristic == toCharacteristic},
+		{name: "optional", equal: from.Optional == to.Optional},
+		{name: "order", equal: from.Order == to.Order},
+	}
+	for _, field := range fields {
+		if !field.equal {
+			changes = append(changes, field.name)
+		}
+	}
+	return changes
+}
+
+// equalParameterValue compares the json representation of both values; nil and empty values are seen as equal
+func equalParameterValue(a interface{}, b interface{}) bool {
+	aJson, _ := json.Marshal(a)
+	bJson, _ := json.Marshal(b)
+	isEmpty := func(value []byte) bool {
+		return slices.Contains([]string{"null", "[]", "{}"}, string(value))
+	}
+	if isEmpty(aJson) && isEmpty(bJson) {
+		return true
+	}
+	return bytes.Equal(aJson, bJson)
+}
+
+func diffMaintenanceProcedures(from []model.MaintenanceProcedure, to []model.MaintenanceProcedure) (result model.MaintenanceProcedureDiffSection) {
+	result = model.MaintenanceProcedureDiffSection{
+		Added:   []model.MaintenanceProcedureDiffEntry{},
+		Removed: []model.MaintenanceProcedureDiffEntry{},
+		Changed: []model.MaintenanceProcedureDiffEntry{},
+	}
+	toEntry := func(procedure model.MaintenanceProcedure) model.MaintenanceProcedureDiffEntry {
+		return model.MaintenanceProcedureDiffEntry{PublicEventId: procedure.PublicEventId, BpmnId: procedure.BpmnId}
+	}
+	for _, fromProcedure := range from {
+		index := slices.IndexFunc(to, func(procedure model.MaintenanceProcedure) bool {
+			return procedure.PublicEventId == fromProcedure.PublicEventId
+		})
+		if index < 0 {
+			result.Removed = append(result.Removed, toEntry(fromProcedure))
+			continue
+		}
+		entry := toEntry(to[index])
+		if fromProcedure.BpmnId != to[index].BpmnId {
+			entry.Changes = append(entry.Changes, "bpmn_id")
+		}
+		parameters := diffParameterDescriptions(fromProcedure.ParameterDescriptions, to[index].ParameterDescriptions)
+		if len(parameters.Added) > 0 || len(parameters.Removed) > 0 || len(parameters.Changed) > 0 {
+			entry.Changes = append(entry.Changes, "parameters")
+			entry.Parameters = &parameters
+		}
+		if len(entry.Changes) > 0 {
+			result.Changed = append(result.Changed, entry)
+		}
+	}
+	for _, toProcedure := range to {
+		if !slices.ContainsFunc(from, func(procedure model.MaintenanceProcedure) bool {
+			return procedure.PublicEventId == toProcedure.PublicEventId
+		}) {
+			result.Added = append(result.Added, toEntry(toProcedure))
+		}
+	}
+	for _, list := range [][]model.MaintenanceProcedureDiffEntry{result.Added, result.Removed, result.Changed} {
+		slices.SortFunc(list, func(a, b model.MaintenanceProcedureDiffEntry) int {
+			return strings.Compare(a.PublicEventId, b.PublicEventId)
+		})
+	}
+	return result
+}
+
+func diffAnalytics(from []model.AnalyticsReleaseModuleInfo, to []model.AnalyticsReleaseModuleInfo) (result model.AnalyticsDiffSection) {
+	result = model.AnalyticsDiffSection{
+		Added:   []model.AnalyticsDiffEntry{},
+		Removed: []model.AnalyticsDiffEntry{},
+		Changed: []model.AnalyticsDiffEntry{},
+	}
+	toEntry := func(info model.AnalyticsReleaseModuleInfo) model.AnalyticsDiffEntry {
+		return model.AnalyticsDiffEntry{FlowId: info.FlowId, Name: info.Name}
+	}
+	for _, fromInfo := range from {
+		index := slices.IndexFunc(to, func(info model.AnalyticsReleaseModuleInfo) bool { return info.FlowId == fromInfo.FlowId })
+		if index < 0 {
+			result.Removed = append(result.Removed, toEntry(fromInfo))
+			continue
+		}
+		entry := toEntry(to[index])
+		if fromInfo.Name != to[index].Name {
+			entry.Changes = append(entry.Changes, "name")
+		}
+		if fromInfo.Desc != to[index].Desc {
+			entry.Changes = append(entry.Changes, "desc")
+		}
+		if len(entry.Changes) > 0 {
+			result.Changed = append(result.Changed, entry)
+		}
+	}
+	for _, toInfo := range to {
+		if !slices.ContainsFunc(from, func(info model.AnalyticsReleaseModuleInfo) bool { return info.FlowId == toInfo.FlowId }) {
+			result.Added = append(result.Added, toEntry(toInfo))
+		}
+	}
+	for _, list := range [][]model.AnalyticsDiffEntry{result.Added, result.Removed, result.Changed} {
+		slices.SortFunc(list, func(a, b model.AnalyticsDiffEntry) int {
+			return strings.Compare(a.FlowId, b.FlowId)
+		})
+	}
+	return result
+}
@@ -18,8 +18,8 @@ package controller
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
+	"net/http"
 	"runtime/debug"
 	"slices"
 	"strconv"
@@ -30,6 +30,20 @@ import (
 	"github.com/beevik/etree"
 )
 
+// parseAndValidateReleaseInfo runs every check of CreateRelease on the design xml without storing or deploying anything
+func (this *Controller) parseAndValidateReleaseInfo(token auth.Token, xml string, element model.SmartServiceRelease) (result model.SmartServiceReleaseInfo, err error, code int) {
+	err = ValidateDesign(this.config, xml)
+	if err != nil {
+		return result, fmt.Errorf("invalid design xml for release: %w", err), http.StatusBadRequest
+	}
+	result, err = this.parseDesignXmlForReleaseInfo(token, xml, element)
+	if err != nil {
+		return result, fmt.Errorf("unable to parse design xml for release: %w", err), http.StatusBadRequest
+	}
+	return result, nil, http.StatusOK
+}
+
+// parseDesignXmlForReleaseInfo returns the first error found by parseReleaseParameters
 func (this *Controller) parseDesignXmlForReleaseInfo(token auth.Token, xml string, element model.SmartServiceRelease) (result model.SmartServiceReleaseInfo, err error) {
 	defer func() {
 		if r := recover(); r != nil && err == nil {
@@ -46,173 +60,259 @@ func (this *Controller) parseDesignXmlForReleaseInfo(token auth.Token, xml strin
 	if err != nil {
 		return result, err
 	}
-	startEvents := doc.FindElements("//bpmn:startEvent")
-	for _, startEvent := range startEvents {
+	diagnostics := &designDiagnostics{}
+	result.ParameterDescriptions, result.MaintenanceProcedures = parseReleaseParameters(doc, element, this.GetCharacteristic, diagnostics)
+	return result, diagnostics.err()
+}
+
+// parseReleaseParameters reads the parameters of the default start-event and the maintenance procedures
+// and adds every problem it finds to diagnostics.
+// getCharacteristic may be nil to skip the characteristic_id lookup.
+func parseReleaseParameters(doc *etree.Document, element model.SmartServiceRelease, getCharacteristic func(id string) (*model.Characteristic, error), diagnostics *designDiagnostics) (parameters []model.ParameterDescription, procedures []model.MaintenanceProcedure) {
+	initElementId := ""
+	knownEventIds := map[string]bool{}
+	for _, startEvent := range doc.FindElements("//bpmn:startEvent") {
+		elementId := startEvent.SelectAttrValue("id", "")
 		parameter := []model.ParameterDescription{}
 		for _, formField := range startEvent.FindElements(".//camunda:formField") {
-			id := formField.SelectAttrValue("id", "")
-			if id == "" {
-				return result, errors.New("missing id in camunda:formField")
+			param, ok := parseFormField(elementId, formField, getCharacteristic, diagnostics)
+			if !ok {
+				continue
 			}
-			label := formField.SelectAttrValue("label", id)
-			if label == "" {
-				label = id
+			if slices.ContainsFunc(parameter, hasParameterId(param.Id)) {
+				diagnostics.errorf("duplicate_param_id", elementId, param.Id, "reuse of %v as param-id", param.Id)
+				continue
 			}
-			fieldType := formField.SelectAttrValue("type", "")
-			if fieldType == "" {
-				return result, errors.New("missing type in camunda:fieldType")
-			}
-			var defaultValue interface{}
-			defaultValueField := formField.SelectAttr("defaultValue")
-			if defaultValueField != nil {
-				switch fieldType {
-				case "string":
-					defaultValue = defaultValueField.Value
-				case "long":
-					defaultValue, err = strconv.ParseFloat(defaultValueField.Value, 64)
-					if err != nil {
-						return result, fmt.Errorf("expect number in camunda:formField %v defaultValue: %w", id, err)
-					}
-				case "boolean":
-					defaultValue, err = strconv.ParseBool(defaultValueField.Value)
-					if err != nil {
-						return result, fmt.Errorf("expect boolean in camunda:formField %v defaultValue: %w", id, err)
-					}
-				}
-
+			parameter = append(parameter, param)
+		}
+		if isMaintenanceProcedure(startEvent) {
+			maintenanceProcedure, ok := createMaintenanceProcedure(doc, startEvent, element, parameter, knownEventIds, diagnostics)
+			if ok {
+				procedures = append(procedures, maintenanceProcedure)
 			}
-			properties := map[string]string{}
-
-			for _, property := range formField.FindElements("./camunda:properties/camunda:property") {
-				propertyId := property.SelectAttrValue("id", "")
-				if propertyId == "" {
-					return result, fmt.Errorf("missing property id in formField %v", id)
-				}
-				properties[propertyId] = property.SelectAttrValue("value", "")
+		} else if isDefaultStartEvent(startEvent) {
+			parameters = parameter
+			initElementId = elementId
+		}
+	}
+	validateSameEntityReferences(initElementId, parameters, nil, diagnostics)
+	for _, maintenanceProcedure := range procedures {
+		for _, param := range maintenanceProcedure.ParameterDescriptions {
+			if slices.ContainsFunc(parameters, hasParameterId(param.Id)) {
+				diagnostics.errorf("duplicate_param_id", maintenanceProcedure.BpmnId, param.Id, "reuse of init-param %v as param-id in maintenance-procedure %v (%v)", param.Id, maintenanceProcedure.PublicEventId, maintenanceProcedure.BpmnId)
 			}
+		}
+		validateSameEntityReferences(maintenanceProcedure.BpmnId, maintenanceProcedure.ParameterDescriptions, parameters, diagnostics)
+	}
+	return parameters, procedures
+}
 
-			param := model.ParameterDescription{
-				Id:           id,
-				Label:        label,
-				Description:  properties["description"],
-				Type:         fieldType,
-				DefaultValue: defaultValue,
-				Optional:     strings.ToLower(strings.TrimSpace(properties["optional"])) == "true",
-			}
-			if order, ok := properties["order"]; ok {
-				param.Order, err = strconv.Atoi(order)
-				if err != nil {
-					return result, fmt.Errorf("invalid order property for formField %v: %w", id, err)
-				}
-			}
-			if chId, ok := properties["characteristic_id"]; ok {
-				param.CharacteristicId = &chId
-				param.Characteristic, err = this.GetCharacteristic(chId)
-				if err != nil {
-					return result, fmt.Errorf("unable to find characteristics_id for formField %v: %w", id, err)
-				}
+// parseFormField returns ok=false if the form field has no id and can not be used as parameter
+func parseFormField(elementId string, formField *etree.Element, getCharacteristic func(id string) (*model.Characteristic, error), diagnostics *designDiagnostics) (param model.ParameterDescription, ok bool) {
+	id := formField.SelectAttrValue("id", "")
+	if id == "" {
+		diagnostics.errorf("missing_field_id", elementId, "", "missing id in camunda:formField")
+		return param, false
+	}
+	label := formField.SelectAttrValue("label", id)
+	if label == "" {
+		label = id
+	}
+	fieldType := formField.SelectAttrValue("type", "")
+	if fieldType == "" {
+		diagnostics.errorf("missing_field_type", elementId, id, "missing type in camunda:formField %v", id)
+	}
+	var defaultValue interface{}
+	var err error
+	defaultValueField := formField.SelectAttr("defaultValue")
+	if defaultValueField != nil {
+		switch fieldType {
+		case "string":
+			defaultValue = defaultValueField.Value
+		case "long":
+			defaultValue, err = strconv.ParseFloat(defaultValueField.Value, 64)
+			if err != nil {
+				diagnostics.errorf("invalid_default_value", elementId, id, "expect number in camunda:formField %v defaultValue: %v", id, err)
 			}
-			if options, ok := properties["options"]; ok {
-				if _, containsCharacteristic := properties["characteristic_id"]; containsCharacteristic {
-					return result, fmt.Errorf("invalid characteristics_id/options property for formField %v: %v", id, "options and characteristics_id are mutual exclusive")
-				}
-				err = json.Unmarshal([]byte(options), &param.Options)
-				if err != nil {
-					return result, fmt.Errorf("invalid options property for formField %v: %w", id, err)
-				}
+		case "boolean":
+			defaultValue, err = strconv.ParseBool(defaultValueField.Value)
+			if err != nil {
+				diagnostics.errorf("invalid_default_value", elementId, id, "expect boolean in camunda:formField %v defaultValue: %v", id, err)
 			}
-			if multiple, ok := properties["multiple"]; ok {
-				param.Multiple, err = strconv.ParseBool(multiple)
-				if err != nil {
-					return result, fmt.Errorf("invalid multiple property for formField %v: %w", id, err)
-				}
+		}
+	}
+
+	properties := map[string]string{}
+	for _, property := range formField.FindElements("./camunda:properties/camunda:property") {
+		propertyId := property.SelectAttrValue("id", "")
+		if propertyId == "" {
+			diagnostics.errorf("missing_property_id", elementId, id, "missing property id in formField %v", id)
+			continue
+		}
+		properties[propertyId] = property.SelectAttrValue("value", "")
+	}
+
+	param = model.ParameterDescription{
+		Id:           id,
+		Label:        label,
+		Description:  properties["description"],
+		Type:         fieldType,
+		DefaultValue: defaultValue,
+		Optional:     strings.ToLower(strings.TrimSpace(properties["optional"])) == "true",
+	}
+	if order, ok := properties["order"]; ok {
+		param.Order, err = strconv.Atoi(order)
+		if err != nil {
+			diagnostics.errorf("invalid_order", elementId, id, "invalid order property for formField %v: %v", id, err)
+		}
+	}
+	_, hasCharacteristic := properties["characteristic_id"]
+	_, hasOptions := properties["options"]
+	iot, hasIot := properties["iot"]
+	if hasCharacteristic {
+		chId := properties["characteristic_id"]
+		param.CharacteristicId = &chId
+		if getCharacteristic != nil {
+			param.Characteristic, err = getCharacteristic(chId)
+			if err != nil {
+				diagnostics.errorf("unknown_characteristic", elementId, id, "unable to find characteristics_id for formField %v: %v", id, err)
 			}
-			if autoSelectAll, ok := properties["auto_select_all"]; ok {
-				param.AutoSelectAll, err = strconv.ParseBool(autoSelectAll)
-				if err != nil {
-					return result, fmt.Errorf("invalid auto_select_all property for formField %v: %w", id, err)
-				}
-				if param.AutoSelectAll && !param.Multiple {
-					return result, fmt.Errorf("auto_select_all property may only be used in combination with multiple for formField %v: %w", id, err)
-				}
+		}
+	}
+	if hasOptions {
+		if hasCharacteristic {
+			diagnostics.errorf("options_characteristic_exclusive", elementId, id, "invalid characteristics_id/options property for formField %v: options and characteristics_id are mutual exclusive", id)
+		}
+		err = json.Unmarshal([]byte(properties["options"]), &param.Options)
+		if err != nil {
+			diagnostics.errorf("invalid_options", elementId, id, "invalid options property for formField %v: %v", id, err)
+		}
+	}
+	if multiple, ok := properties["multiple"]; ok {
+		param.Multiple, err = strconv.ParseBool(multiple)
+		if err != nil {
+			diagnostics.errorf("invalid_multiple", elementId, id, "invalid multiple property for formField %v: %v", id, err)
+		}
+	}
+	if autoSelectAll, ok := properties["auto_select_all"]; ok {
+		param.AutoSelectAll, err = strconv.ParseBool(autoSelectAll)
+		if err != nil {
+			diagnostics.errorf("invalid_auto_select_all", elementId, id, "invalid auto_select_all property for formField %v: %v", id, err)
+		} else if param.AutoSelectAll && !param.Multiple {
+			diagnostics.errorf("auto_select_all_without_multiple", elementId, id, "auto_select_all property may only be used in combination with multiple for formField %v", id)
+		}
+	}
+	if !hasIot {
+		for _, iotOnly := range []string{"criteria", "criteria_list", "entity_only", "same_entity"} {
+			if _, ok := properties[iotOnly]; ok {
+				diagnostics.warnf("ignored_property", elementId, id, "%v property of formField %v is ignored without iot property", iotOnly, id)
 			}
-			if iot, ok := properties["iot"]; ok {
-				if _, containsOptions := properties["options"]; containsOptions {
-					return result, fmt.Errorf("invalid options/iot property for formField %v: %v", id, "iot and options are mutual exclusive")
-				}
-				if _, containsCharacteristic := properties["characteristic_id"]; containsCharacteristic {
-					return result, fmt.Errorf("invalid characteristics_id/iot property for formField %v: %v", id, "iot and characteristics_id are mutual exclusive")
-				}
-				typeFilter := []string{}
-				iot = strings.ReplaceAll(iot, " ", "")
-				if iot != "" {
-					typeFilter = strings.Split(iot, ",")
-				}
-				criteria := []model.Criteria{}
-				if criteriaStr, hasCriteria := properties["criteria"]; hasCriteria {
-					temp := model.Criteria{}
-					err = json.Unmarshal([]byte(criteriaStr), &temp)
-					if err != nil {
-						return result, fmt.Errorf("invalid criteria property for formField %v: %w", id, err)
-					}
-					criteria = []model.Criteria{temp}
-				}
-				if criteriaStr, hasCriteria := properties["criteria_list"]; hasCriteria {
-					err = json.Unmarshal([]byte(criteriaStr), &criteria)
-					if err != nil {
-						return result, fmt.Errorf("invalid criteria property for formField %v: %w", id, err)
-					}
-				}
+		}
+		return param, true
+	}
+	if hasOptions {
+		diagnostics.errorf("iot_options_exclusive", elementId, id, "invalid options/iot property for formField %v: iot and options are mutual exclusive", id)
+	}
+	if hasCharacteristic {
+		diagnostics.errorf("iot_characteristic_exclusive", elementId, id, "invalid characteristics_id/iot property for formField %v: iot and characteristics_id are mutual exclusive", id)
+	}
+	typeFilter := []string{}
+	iot = strings.ReplaceAll(iot, " ", "")
+	if iot != "" {
+		typeFilter = strings.Split(iot, ",")
+	}
+	for _, filter := range typeFilter {
+		if !slices.Contains([]model.FilterPossibility{model.DeviceFilter, model.DeviceServiceGroupFilter, model.GroupFilter, model.ImportFilter}, filter) {
+			diagnostics.warnf("unknown_iot_type", elementId, id, "unknown iot type %v in formField %v", filter, id)
+		}
+	}
+	criteria := []model.Criteria{}
+	criteriaStr, hasCriteria := properties["criteria"]
+	if hasCriteria {
+		temp := model.Criteria{}
+		err = json.Unmarshal([]byte(criteriaStr), &temp)
+		if err != nil {
+			diagnostics.errorf("invalid_criteria", elementId, id, "invalid criteria property for formField %v: %v", id, err)
+		}
+		criteria = []model.Criteria{temp}
+	}
+	if criteriaListStr, hasCriteriaList := properties["criteria_list"]; hasCriteriaList {
+		err = json.Unmarshal([]byte(criteriaListStr), &criteria)
+		if err != nil {
+			diagnostics.errorf("invalid_criteria", elementId, id, "invalid criteria_list property for formField %v: %v", id, err)
+		}
+		if hasCriteria {
+			diagnostics.warnf("ignored_property", elementId, id, "criteria property of formField %v is overwritten by criteria_list", id)
+		}
+	}
 
-				entityOnly := false
-				if entityOnlyStr, hasEntityOnly := properties["entity_only"]; hasEntityOnly {
-					entityOnly, _ = strconv.ParseBool(entityOnlyStr)
-				}
+	entityOnly := false
+	if entityOnlyStr, hasEntityOnly := properties["entity_only"]; hasEntityOnly {
+		entityOnly, err = strconv.ParseBool(entityOnlyStr)
+		if err != nil {
+			diagnostics.warnf("invalid_entity_only", elementId, id, "invalid entity_only property for formField %v is interpreted as false: %v", id, err)
+		}
+	}
 
-				sameEntity := properties["same_entity"]
+	param.IotDescription = &model.IotDescription{
+		TypeFilter:                   typeFilter,
+		Criteria:                     criteria,
+		EntityOnly:                   entityOnly,
+		NeedsSameEntityIdInParameter: properties["same_entity"],
+	}
+	return param, true
+}
 
-				param.IotDescription = &model.IotDescription{
-					TypeFilter:                   typeFilter,
-					Criteria:                     criteria,
-					EntityOnly:                   entityOnly,
-					NeedsSameEntityIdInParameter: sameEntity,
-				}
-			}
-			parameter = append(parameter, param)
+// validateSameEntityReferences checks that every same_entity property references a parameter of the same start-event
+// or, for maintenance procedures, one of the init parameters
+func validateSameEntityReferences(elementId string, parameter []model.ParameterDescription, initParameter []model.ParameterDescription, diagnostics *designDiagnostics) {
+	for _, param := range parameter {
+		if param.IotDescription == nil || param.IotDescription.NeedsSameEntityIdInParameter == "" {
+			continue
 		}
-		if isMaintenanceProcedure(startEvent) {
-			maintenanceProcedure, err := createMaintenanceProcedure(doc, startEvent, element, parameter)
-			if err != nil {
-				return result, err
-			}
-			result.MaintenanceProcedures = append(result.MaintenanceProcedures, maintenanceProcedure)
-		} else {
-			result.ParameterDescriptions = parameter
+		ref := param.IotDescription.NeedsSameEntityIdInParameter
+		if !slices.ContainsFunc(parameter, hasParameterId(ref)) && !slices.ContainsFunc(initParameter, hasParameterId(ref)) {
+			diagnostics.errorf("unknown_same_entity", elementId, param.Id, "%v: parameter property \"same_entity\" references unknown parameter \"%v\"", param.Id, ref)
 		}
 	}
+}
 
-	return result, nil
+func hasParameterId(id string) func(model.ParameterDescription) bool {
+	return func(param model.ParameterDescription) bool {
+		return param.Id == id
+	}
 }
 
-func createMaintenanceProcedure(doc *etree.Document, event *etree.Element, element model.SmartServiceRelease, parameter []model.ParameterDescription) (result model.MaintenanceProcedure, err error) {
+// createMaintenanceProcedure returns ok=false if the message of the event can not be resolved
+func createMaintenanceProcedure(doc *etree.Document, event *etree.Element, element model.SmartServiceRelease, parameter []model.ParameterDescription, knownEventIds map[string]bool, diagnostics *designDiagnostics) (result model.MaintenanceProcedure, ok bool) {
 	result.ParameterDescriptions = parameter
 	result.BpmnId = event.SelectAttrValue("id", "")
 	msgEvent := event.FindElement(".//bpmn:messageEventDefinition")
 	if msgEvent == nil {
-		return result, fmt.Errorf("missing bpmn:messageEventDefinition for %v", result.BpmnId)
+		diagnostics.errorf("missing_message_ref", result.BpmnId, "", "missing bpmn:messageEventDefinition for %v", result.BpmnId)
+		return result, false
 	}
 	result.MessageRef = msgEvent.SelectAttrValue("messageRef", "")
 	if result.MessageRef == "" {
-		return result, fmt.Errorf("missing messageRef for %v", result.BpmnId)
+		if msgEvent.SelectAttr("messageRef") != nil {
+			diagnostics.errorf("missing_message_ref", result.BpmnId, "", "missing messageRef for %v", result.BpmnId)
+		} //else: already reported by validateStartEvents
+		return result, false
 	}
 	msgRefElement := doc.FindElement("//bpmn:message[@id='" + result.MessageRef + "']")
 	if msgRefElement == nil {
-		return result, fmt.Errorf("unknown messageRef %v for %v", result.MessageRef, result.BpmnId)
+		diagnostics.errorf("unknown_message_ref", result.BpmnId, "", "unknown messageRef %v for %v", result.MessageRef, result.BpmnId)
+		return result, false
 	}
 	result.PublicEventId = msgRefElement.SelectAttrValue("name", "")
 	result.InternalEventId = element.Id + "_" + result.PublicEventId
-
-	return result, nil
+	if result.PublicEventId == "" {
+		diagnostics.errorf("missing_message_name", result.BpmnId, "", "empty msg-event-id for maintenance-procedure in %v", result.BpmnId)
+	} else if knownEventIds[result.PublicEventId] {
+		diagnostics.errorf("duplicate_message_name", result.BpmnId, "", "reuse of %v as msg-event-id for maintenance-procedure in %v", result.PublicEventId, result.BpmnId)
+	}
+	knownEventIds[result.PublicEventId] = true
+	return result, true
 }
 
 func isMaintenanceProcedure(element *etree.Element) bool {
@@ -223,48 +323,14 @@ func isMaintenanceProcedure(element *etree.Element) bool {
 	return true
 }
 
-func (this *Controller) validateParsedReleaseInfos(info model.SmartServiceReleaseInfo) error {
-	knownInitParams := map[string]bool{}
-	for _, param := range info.ParameterDescriptions {
-		if ok := knownInitParams[param.Id]; ok {
-			return fmt.Errorf("reuse of %v as param-id", param.Id)
-		}
-		knownInitParams[param.Id] = true
-		if param.AutoSelectAll && !param.Multiple {
-			return fmt.Errorf("%v: parameter property \"auto_select_all\" may only be used in combination with  \"multiple\"", param.Id)
-		}
-		if param.IotDescription != nil {
-			if param.IotDescription.NeedsSameEntityIdInParameter != "" {
-				if !slices.ContainsFunc(info.ParameterDescriptions, func(p model.ParameterDescription) bool {
-					return p.Id == param.IotDescription.NeedsSameEntityIdInParameter
-				}) {
-					return fmt.Errorf("%v: parameter property \"entity_only\" references unknown parameter \"%v\"", param.Id, param.IotDescription.NeedsSameEntityIdInParameter)
-				}
-			}
+// isDefaultStartEvent is true for start-events without event definition; other start-events are rejected by validateStartEvents
+func isDefaultStartEvent(element *etree.Element) bool {
+	for _, child := range element.ChildElements() {
+		if strings.HasSuffix(child.Tag, "EventDefinition") {
+			return false
 		}
 	}
-	knownEventIds := map[string]bool{}
-	for _, maintenanceProcedure := range info.MaintenanceProcedures {
-		if maintenanceProcedure.PublicEventId == "" {
-			return fmt.Errorf("empty msg-event-id for maintenance-procedure in %v", maintenanceProcedure.BpmnId)
-		}
-		if ok := knownEventIds[maintenanceProcedure.PublicEventId]; ok {
-			return fmt.Errorf("reuse of %v as msg-event-id for maintenance-procedure in %v", maintenanceProcedure.PublicEventId, maintenanceProcedure.BpmnId)
-		}
-		knownEventIds[maintenanceProcedure.PublicEventId] = true
-
-		knownParams := map[string]bool{}
-		for _, param := range maintenanceProcedure.ParameterDescriptions {
-			if ok := knownInitParams[param.Id]; ok {
-				return fmt.Errorf("reuse of init-param %v as param-id in maintenance-procedure %v (%v)", param.Id, maintenanceProcedure.PublicEventId, maintenanceProcedure.BpmnId)
-			}
-			if ok := knownParams[param.Id]; ok {
-				return fmt.Errorf("reuse of %v as param-id in maintenance-procedure %v (%v)", param.Id, maintenanceProcedure.PublicEventId, maintenanceProcedure.BpmnId)
-			}
-			knownParams[param.Id] = true
-		}
-	}
-	return nil
+	return true
 }
 
 const AnalyticsTopic = "analytics"
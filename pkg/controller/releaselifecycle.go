@@ -0,0 +1,166 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/notification"
+)
+
+// pending_approval releases change their state with ApproveRelease() and RejectRelease()
+var releaseLifecycleTransitions = map[string][]string{
+	model.ReleaseLifecycleStateDraft:           {model.ReleaseLifecycleStatePublished, model.ReleaseLifecycleStatePendingApproval}, //drafts may be deleted instead of retired; retired releases could otherwise be reactivated without approval
+	model.ReleaseLifecycleStatePublished:       {model.ReleaseLifecycleStateDeprecated, model.ReleaseLifecycleStateRetired},
+	model.ReleaseLifecycleStateDeprecated:      {model.ReleaseLifecycleStatePublished, model.ReleaseLifecycleStateRetired},
+	model.ReleaseLifecycleStateRetired:         {model.ReleaseLifecycleStateDeprecated},
+	model.ReleaseLifecycleStatePendingApproval: {},
+	model.ReleaseLifecycleStateRejected:        {},
+}
+
+func (this *Controller) SetReleaseLifecycleState(token auth.Token, id string, update model.SmartServiceReleaseLifecycleStateUpdate) (result model.SmartServiceRelease, err error, code int) {
+	access, err, _ := this.permissions.CheckPermission(token.Jwt(), this.config.SmartServiceReleasePermissionsTopic, id, client.Administrate)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !access {
+		return result, errors.New("access denied"), http.StatusForbidden
+	}
+	release, err, code := this.db.GetRelease(id, false)
+	if err != nil {
+		return result, err, code
+	}
+	current := release.GetLifecycleState()
+	if current == update.LifecycleState {
+		return release.SmartServiceRelease, nil, http.StatusOK
+	}
+	if _, known := releaseLifecycleTransitions[update.LifecycleState]; !known {
+		return result, fmt.Errorf("unknown lifecycle_state %v", update.LifecycleState), http.StatusBadRequest
+	}
+	if !slices.Contains(releaseLifecycleTransitions[current], update.LifecycleState) {
+		return result, fmt.Errorf("lifecycle_state of release may not change from %v to %v", current, update.LifecycleState), http.StatusBadRequest
+	}
+	if current == model.ReleaseLifecycleStateDraft && update.LifecycleState == model.ReleaseLifecycleStatePublished && !this.isReleasePublisher(token) {
+		update.LifecycleState = model.ReleaseLifecycleStatePendingApproval
+	}
+	release.LifecycleState = update.LifecycleState
+	if current == model.ReleaseLifecycleStateDraft {
+		err = this.deployPublishedDraft(release)
+		if err != nil {
+			return result, err, http.StatusInternalServerError
+		}
+	}
+	err, code = this.db.SetRelease(release, false)
+	if err != nil {
+		return result, err, code
+	}
+	if update.LifecycleState == model.ReleaseLifecycleStatePendingApproval {
+		this.notifyReviewersOfPendingRelease(release)
+	}
+	if current == model.ReleaseLifecycleStateDraft && update.LifecycleState == model.ReleaseLifecycleStatePublished {
+		oldReleases, err := this.getOldReleases(release)
+		if err != nil {
+			return result, err, http.StatusInternalServerError
+		}
+		err = this.replaceOldReleases(release, oldReleases)
+		if err != nil {
+			return result, err, http.StatusInternalServerError
+		}
+	}
+	if update.LifecycleState == model.ReleaseLifecycleStateDeprecated || update.LifecycleState == model.ReleaseLifecycleStateRetired {
+		err = this.notifyInstanceOwnersOfLifecycleState(release, update)
+		if err != nil {
+			this.config.GetLogger().Error("unable to notify instance owners about release lifecycle_state", "error", err, "releaseId", release.Id)
+		}
+	}
+	return release.SmartServiceRelease, nil, http.StatusOK
+}
+
+// deployPublishedDraft deploys a draft that is published or removes its deployment if it is waiting for approval.
+// drafts are not deployed; older drafts may still have a deployment.
+func (this *Controller) deployPublishedDraft(release model.SmartServiceReleaseExtended) error {
+	this.cleanupMux.Lock()
+	defer this.cleanupMux.Unlock()
+	if !release.IsReleased() {
+		return this.camunda.RemoveRelease(release.Id) //deployed by ApproveRelease()
+	}
+	deployed, err := this.camunda.IsReleaseDeployed(release.Id)
+	if err != nil {
+		return err
+	}
+	if !deployed {
+		err, _ = this.camunda.DeployRelease(release.Creator, release)
+	}
+	return err
+}
+
+func (this *Controller) notifyInstanceOwnersOfLifecycleState(release model.SmartServiceReleaseExtended, update model.SmartServiceReleaseLifecycleStateUpdate) error {
+	instances, err, _ := this.db.ListInstancesOfRelease("", release.Id)
+	if err != nil {
+		return err
+	}
+	instanceNamesByUser := map[string][]string{}
+	for _, instance := range instances {
+		instanceNamesByUser[instance.UserId] = append(instanceNamesByUser[instance.UserId], instance.Name)
+	}
+	title := "Smart-Service-Release Deprecated"
+	consequence := "Your instances keep running, but you should switch to a newer release."
+	if update.LifecycleState == model.ReleaseLifecycleStateRetired {
+		title = "Smart-Service-Release Retired"
+		consequence = "Your instances may no longer be redeployed with this release."
+	}
+	for userId, instanceNames := range instanceNamesByUser {
+		message := fmt.Sprintf("%s \nRelease-Name: %s \nRelease-ID: %s \nInstances: %s \n%s", title, release.Name, release.Id, strings.Join(instanceNames, ", "), consequence)
+		if update.Message != "" {
+			message = message + " \nMessage: " + update.Message
+		}
+		_ = notification.Send(this.config.NotificationUrl, notification.Message{
+			UserId:  userId,
+			Title:   title,
+			Message: message,
+		}, this.config.GetLogger())
+	}
+	return nil
+}
+
+// checkReleaseVisibility returns an error if the release is a draft or an unapproved release of another user
+func checkReleaseVisibility(token auth.Token, release model.SmartServiceRelease) (err error, code int) {
+	if !release.IsReleased() && release.Creator != token.GetUserId() {
+		return fmt.Errorf("access denied: release is in lifecycle_state %v and only visible to its creator", release.GetLifecycleState()), http.StatusForbidden
+	}
+	return nil, http.StatusOK
+}
+
+// checkReleaseUsable returns an error if the release may not be used to create or redeploy instances
+func checkReleaseUsable(release model.SmartServiceRelease) (err error, code int) {
+	switch release.GetLifecycleState() {
+	case model.ReleaseLifecycleStateRetired:
+		return fmt.Errorf("release %v (%v) is retired and may no longer be used for instances", release.Name, release.Id), http.StatusBadRequest
+	case model.ReleaseLifecycleStateDraft:
+		return fmt.Errorf("release %v (%v) is a draft and may not be used for instances until it is published", release.Name, release.Id), http.StatusBadRequest
+	case model.ReleaseLifecycleStatePendingApproval, model.ReleaseLifecycleStateRejected:
+		return fmt.Errorf("release %v (%v) has not been approved and may not be used for instances", release.Name, release.Id), http.StatusBadRequest
+	}
+	return nil, http.StatusOK
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+)
+
+// GetReleasePermissions returns the users, groups and roles with access to the release; needs administrate rights
+func (this *Controller) GetReleasePermissions(token auth.Token, id string) (result model.ReleasePermissions, err error, code int) {
+	release, err, code := this.getAdministrableRelease(token, id)
+	if err != nil {
+		return result, err, code
+	}
+	resource, err, code := this.permissions.GetResource(token.Jwt(), this.config.SmartServiceReleasePermissionsTopic, release.Id)
+	if err != nil {
+		return result, err, code
+	}
+	return normalizeReleasePermissions(resource.ResourcePermissions), nil, http.StatusOK
+}
+
+// SetReleasePermissions replaces the permissions of the release.
+// if propagate is true, the permissions are also set for all other versions of the design the user may administrate;
+// versions where this fails are listed in FailedReleaseIds.
+func (this *Controller) SetReleasePermissions(token auth.Token, id string, permissions model.ReleasePermissions, propagate bool) (result model.ReleasePermissionsUpdateResult, err error, code int) {
+	permissions = normalizeReleasePermissions(permissions)
+	err = validateReleasePermissions(permissions)
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	release, err, code := this.getAdministrableRelease(token, id)
+	if err != nil {
+		return result, err, code
+	}
+	result = model.ReleasePermissionsUpdateResult{
+		UpdatedReleaseIds: []string{},
+		SkippedReleaseIds: []string{},
+		FailedReleaseIds:  []string{},
+	}
+	ids := []string{release.Id}
+	if propagate {
+		siblings, err := this.getOldReleases(release)
+		if err != nil {
+			return result, err, http.StatusInternalServerError
+		}
+		siblingIds := []string{}
+		for _, sibling := range siblings {
+			if !sibling.IsReleased() && sibling.Creator != token.GetUserId() {
+				continue //drafts and unapproved releases of other users are not shared
+			}
+			siblingIds = append(siblingIds, sibling.Id)
+		}
+		if len(siblingIds) > 0 {
+			access, err, _ := this.permissions.CheckMultiplePermissions(token.Jwt(), this.config.SmartServiceReleasePermissionsTopic, siblingIds, client.Administrate)
+			if err != nil {
+				return result, err, http.StatusInternalServerError
+			}
+			for _, siblingId := range siblingIds {
+				if access[siblingId] {
+					ids = append(ids, siblingId)
+				} else {
+					result.SkippedReleaseIds = append(result.SkippedReleaseIds, siblingId)
+				}
+			}
+		}
+	}
+	for _, releaseId := range ids {
+		updated, err, code := this.permissions.SetPermission(token.Jwt(), this.config.SmartServiceReleasePermissionsTopic, releaseId, permissions)
+		if err != nil && releaseId == release.Id {
+			return result, err, code
+		}
+		if err != nil {
+			//the requested release is already updated, so the remaining versions are still tried
+			this.config.GetLogger().Warn("unable to propagate release permissions", "releaseId", releaseId, "sourceReleaseId", release.Id, "error", err)
+			result.FailedReleaseIds = append(result.FailedReleaseIds, releaseId)
+			continue
+		}
+		if releaseId == release.Id {
+			result.Permissions = normalizeReleasePermissions(updated)
+		}
+		result.UpdatedReleaseIds = append(result.UpdatedReleaseIds, releaseId)
+	}
+	sort.Strings(result.SkippedReleaseIds)
+	sort.Strings(result.FailedReleaseIds)
+	return result, nil, http.StatusOK
+}
+
+func (this *Controller) getAdministrableRelease(token auth.Token, id string) (release model.SmartServiceReleaseExtended, err error, code int) {
+	access, err, _ := this.permissions.CheckPermission(token.Jwt(), this.config.SmartServiceReleasePermissionsTopic, id, client.Administrate)
+	if err != nil {
+		return release, err, http.StatusInternalServerError
+	}
+	if !access {
+		return release, errors.New("access denied"), http.StatusForbidden
+	}
+	release, err, code = this.db.GetRelease(id, false)
+	if err != nil {
+		return release, err, code
+	}
+	err, code = checkReleaseVisibility(token, release.SmartServiceRelease)
+	if err != nil {
+		return release, err, code
+	}
+	return release, nil, http.StatusOK
+}
+
+func validateReleasePermissions(permissions model.ReleasePermissions) error {
+	if !permissions.Valid() {
+		return errors.New("at least one user needs administrate rights")
+	}
+	for _, list := range []map[string]client.PermissionsMap{permissions.UserPermissions, permissions.GroupPermissions, permissions.RolePermissions} {
+		for key, perm := range list {
+			if key == "" {
+				return errors.New("missing user, group or role id")
+			}
+			if (perm.Write || perm.Execute || perm.Administrate) && !perm.Read {
+				return errors.New("write, execute and administrate rights need read rights")
+			}
+		}
+	}
+	return nil
+}
+
+func normalizeReleasePermissions(permissions model.ReleasePermissions) model.ReleasePermissions {
+	if permissions.UserPermissions == nil {
+		permissions.UserPermissions = map[string]client.PermissionsMap{}
+	}
+	if permissions.GroupPermissions == nil {
+		permissions.GroupPermissions = map[string]client.PermissionsMap{}
+	}
+	if permissions.RolePermissions == nil {
+		permissions.RolePermissions = map[string]client.PermissionsMap{}
+	}
+	return permissions
+}
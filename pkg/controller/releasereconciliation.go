@@ -0,0 +1,143 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+)
+
+// ReconcileReleases compares the finished releases with the process definitions deployed in camunda.
+// releases missing in camunda are redeployed and process definitions without release are reported;
+// they are only removed if config.ReleaseReconciliationRemoveOrphans is set, because camunda may be shared with other services.
+// if dryRun is true, the differences are only reported.
+func (this *Controller) ReconcileReleases(dryRun bool) (result model.ReleaseReconciliationReport, err error, code int) {
+	this.cleanupMux.Lock()
+	defer this.cleanupMux.Unlock()
+	result = this.releaseReconciliation(dryRun)
+	return result, nil, http.StatusOK
+}
+
+// GetLastReleaseReconciliation returns the report of the last reconciliation that was not a dry run
+func (this *Controller) GetLastReleaseReconciliation() (result model.ReleaseReconciliationReport, err error, code int) {
+	this.reconciliationMux.Lock()
+	defer this.reconciliationMux.Unlock()
+	if this.reconciliation == nil {
+		return result, errors.New("no release reconciliation found"), http.StatusNotFound
+	}
+	return *this.reconciliation, nil, http.StatusOK
+}
+
+// releaseReconciliation expects the cleanupMux to be locked
+func (this *Controller) releaseReconciliation(dryRun bool) (result model.ReleaseReconciliationReport) {
+	result = model.ReleaseReconciliationReport{
+		StartedAt:           time.Now().Unix(),
+		DryRun:              dryRun,
+		Redeployed:          []string{},
+		OrphanedDeployments: []string{},
+		RemovedDeployments:  []string{},
+	}
+	defer func() {
+		result.FinishedAt = time.Now().Unix()
+		if !dryRun {
+			this.reconciliationMux.Lock()
+			defer this.reconciliationMux.Unlock()
+			this.reconciliation = &result
+		}
+	}()
+	addError := func(err error) {
+		result.Errors = append(result.Errors, err.Error())
+	}
+
+	//read camunda before the database: releases created in between are already marked in the database and not seen as orphaned
+	deployedKeys, err := this.camunda.GetDeployedReleaseKeys()
+	if err != nil {
+		this.config.GetLogger().Error("unable to list camunda process definitions for release reconciliation", "error", err)
+		addError(err)
+		return result
+	}
+	finished, marked, err := this.db.GetAllReleases()
+	if err != nil {
+		this.config.GetLogger().Error("unable to list releases for release reconciliation", "error", err)
+		addError(err)
+		return result
+	}
+	result.CheckedReleases = len(finished)
+
+	deployed := map[string]bool{}
+	for _, key := range deployedKeys {
+		deployed[key] = true
+	}
+	known := map[string]bool{}
+	for _, release := range marked {
+		known[this.camunda.ReleaseIdToKey(release.Id)] = true
+	}
+
+	for _, release := range finished {
+		key := this.camunda.ReleaseIdToKey(release.Id)
+		known[key] = true
+		if deployed[key] {
+			continue
+		}
+		if !release.IsReleased() {
+			continue //deployed when published or approved
+		}
+		this.config.GetLogger().Info("found release without camunda deployment --> redeploy", "releaseId", release.Id, "dryRun", dryRun)
+		if !dryRun {
+			err, _ = this.camunda.DeployRelease(release.Creator, release)
+			if err != nil {
+				this.config.GetLogger().Error("unable to redeploy release", "releaseId", release.Id, "error", err)
+				addError(err)
+				continue
+			}
+		}
+		result.Redeployed = append(result.Redeployed, release.Id)
+	}
+
+	for _, key := range deployedKeys {
+		if !known[key] {
+			result.OrphanedDeployments = append(result.OrphanedDeployments, key)
+		}
+	}
+	if !this.config.ReleaseReconciliationRemoveOrphans {
+		if len(result.OrphanedDeployments) > 0 {
+			this.config.GetLogger().Warn("found orphaned camunda deployments --> only reported because release_reconciliation_remove_orphans is disabled", "keys", result.OrphanedDeployments)
+		}
+		return result
+	}
+	if len(finished) == 0 && len(marked) == 0 && len(deployedKeys) > 0 {
+		//protects camunda from an empty or wrongly configured database
+		this.config.GetLogger().Warn("no releases found in database but camunda has release deployments --> skip removal of orphaned deployments", "deployments", len(deployedKeys))
+		return result
+	}
+	for _, key := range result.OrphanedDeployments {
+		this.config.GetLogger().Info("found orphaned camunda deployment --> remove", "key", key, "dryRun", dryRun)
+		if !dryRun {
+			err = this.camunda.RemoveRelease(key)
+			if err != nil {
+				this.config.GetLogger().Error("unable to remove orphaned camunda deployment", "key", key, "error", err)
+				addError(err)
+				continue
+			}
+		}
+		result.RemovedDeployments = append(result.RemovedDeployments, key)
+	}
+	return result
+}
@@ -47,22 +47,42 @@ func (this *Controller) retryMarkedReleases() {
 		}
 	}
 	for _, release := range unfinised {
+		if _, running := this.runningReleaseCreations.Load(release.Id); running {
+			continue //still pending or deploying; creations interrupted by a restart are no longer running
+		}
+		creation, _, _ := this.db.GetReleaseCreation(release.Id) //releases created before the creation status existed have no entry
 		err = this.deleteRelease(release.Id)
 		if err != nil {
 			this.config.GetLogger().Error("error in retryMarkedReleases()::deleteRelease()", "error", err, "releaseId", release.Id)
 			return
 		}
+		if creation.ReleaseId != "" {
+			creation.Status = model.ReleaseCreationStatusFailed
+			creation.Error = "release deployment has been interrupted"
+			this.setReleaseCreationStatus(creation)
+		}
 	}
 }
 
+// CreateRelease creates the release synchronously
 func (this *Controller) CreateRelease(token auth.Token, element model.SmartServiceRelease) (result model.SmartServiceRelease, err error, code int) {
+	return this.createRelease(token, element, true)
+}
+
+// CreateReleaseAsync validates the request and returns the release with http.StatusAccepted.
+// the design is parsed and deployed in the background; the progress may be checked with GetReleaseCreation.
+func (this *Controller) CreateReleaseAsync(token auth.Token, element model.SmartServiceRelease) (result model.SmartServiceRelease, err error, code int) {
+	return this.createRelease(token, element, false)
+}
+
+func (this *Controller) createRelease(token auth.Token, element model.SmartServiceRelease, wait bool) (result model.SmartServiceRelease, err error, code int) {
 	if element.DesignId == "" {
 		return result, errors.New("missing design id"), http.StatusBadRequest
 	}
 	design, err, code := this.GetDesign(token, element.DesignId)
 	if err != nil {
-		if code == http.StatusNotFound {
-			return result, fmt.Errorf("user does not own a smart-service-design with the id %v", element.DesignId), http.StatusBadRequest
+		if code == http.StatusNotFound || code == http.StatusForbidden {
+			return result, fmt.Errorf("user has no access to a smart-service-design with the id %v", element.DesignId), http.StatusBadRequest
 		}
 		return result, err, http.StatusInternalServerError
 	}
@@ -72,6 +92,10 @@ func (this *Controller) CreateRelease(token auth.Token, element model.SmartServi
 	if element.Description == "" {
 		element.Description = design.Description
 	}
+	err = this.setReleaseCatalogDefaults(&element, design.BpmnXml)
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
 	element.CreatedAt = time.Now().Unix()
 	element.Creator = token.GetUserId()
 
@@ -79,31 +103,151 @@ func (this *Controller) CreateRelease(token auth.Token, element model.SmartServi
 		element.Id = this.GetNewId()
 	}
 
-	err = ValidateDesign(this.config, design.BpmnXml)
+	switch element.LifecycleState {
+	case "":
+		element.LifecycleState = model.ReleaseLifecycleStatePublished
+	case model.ReleaseLifecycleStateDraft, model.ReleaseLifecycleStatePublished:
+	default:
+		return result, fmt.Errorf("new releases may only have the lifecycle_state %v or %v", model.ReleaseLifecycleStateDraft, model.ReleaseLifecycleStatePublished), http.StatusBadRequest
+	}
+	if element.LifecycleState == model.ReleaseLifecycleStatePublished && !this.isReleasePublisher(token) {
+		element.LifecycleState = model.ReleaseLifecycleStatePendingApproval
+	}
+	element.Approval = nil
+
+	creation := model.SmartServiceReleaseCreation{
+		ReleaseId: element.Id,
+		DesignId:  element.DesignId,
+		Name:      element.Name,
+		UserId:    element.Creator,
+		Status:    model.ReleaseCreationStatusPending,
+		CreatedAt: element.CreatedAt,
+		UpdatedAt: element.CreatedAt,
+	}
+	err, code = this.db.SetReleaseCreation(creation)
 	if err != nil {
-		return result, fmt.Errorf("invalid design xml for release: %w", err), http.StatusBadRequest
+		return result, err, code
 	}
 
-	parsedInfo, err := this.parseDesignXmlForReleaseInfo(token, design.BpmnXml, element)
+	if !wait {
+		go this.runReleaseCreationAsync(creation, element, design)
+		return element, nil, http.StatusAccepted
+	}
+	err, code = this.runReleaseCreation(token, creation, element, design)
 	if err != nil {
-		return result, fmt.Errorf("unable to parse design xml for release: %w", err), http.StatusBadRequest
+		return result, err, code
 	}
-	err = this.validateParsedReleaseInfos(parsedInfo)
+	return element, nil, http.StatusOK
+}
+
+// runReleaseCreationAsync uses a new token of the creator, because the token of the request may expire before the creation is finished
+func (this *Controller) runReleaseCreationAsync(creation model.SmartServiceReleaseCreation, element model.SmartServiceRelease, design model.SmartServiceDesign) {
+	token, err := this.userTokenProvider(creation.UserId)
 	if err != nil {
-		return result, err, http.StatusBadRequest
+		this.config.GetLogger().Error("unable to get token for release creation", "error", err, "releaseId", creation.ReleaseId)
+		creation.Status = model.ReleaseCreationStatusFailed
+		creation.Error = err.Error()
+		this.setReleaseCreationStatus(creation)
+		return
 	}
+	this.runReleaseCreation(token, creation, element, design)
+}
+
+// runReleaseCreation parses and deploys the release while updating the status of the creation
+func (this *Controller) runReleaseCreation(token auth.Token, creation model.SmartServiceReleaseCreation, element model.SmartServiceRelease, design model.SmartServiceDesign) (err error, code int) {
+	this.runningReleaseCreations.Store(creation.ReleaseId, true)
+	defer this.runningReleaseCreations.Delete(creation.ReleaseId)
+	defer func() {
+		if r := recover(); r != nil && err == nil {
+			err = fmt.Errorf("panic in runReleaseCreation: %v", r)
+			code = http.StatusInternalServerError
+			this.config.GetLogger().Error("Recovered Error", "error", r, "stack", string(debug.Stack()))
+		}
+		if err != nil {
+			creation.Status = model.ReleaseCreationStatusFailed
+			creation.Error = err.Error()
+		} else {
+			creation.Status = model.ReleaseCreationStatusReady
+		}
+		this.setReleaseCreationStatus(creation)
+	}()
 
-	err = this.saveReleaseCreate(model.SmartServiceReleaseExtended{
+	parsedInfo, err, code := this.parseAndValidateReleaseInfo(token, design.BpmnXml, element)
+	if err != nil {
+		return err, code
+	}
+
+	creation.Status = model.ReleaseCreationStatusDeploying
+	this.setReleaseCreationStatus(creation)
+
+	release := model.SmartServiceReleaseExtended{
 		SmartServiceRelease: element,
 		BpmnXml:             design.BpmnXml,
 		SvgXml:              design.SvgXml,
 		ParsedInfo:          parsedInfo,
-	})
+	}
+	err = this.saveReleaseCreate(release)
 	if err != nil {
-		return result, err, http.StatusInternalServerError
+		return err, http.StatusInternalServerError
+	}
+	if release.GetLifecycleState() == model.ReleaseLifecycleStatePendingApproval {
+		this.notifyReviewersOfPendingRelease(release)
 	}
+	return nil, http.StatusOK
+}
 
-	return element, nil, http.StatusOK
+// failInterruptedReleaseCreations marks creations as failed, which were still pending or deploying when the service stopped.
+// releases of deploying creations are marked as unfinished and removed by retryMarkedReleases.
+func failInterruptedReleaseCreations(db Database) error {
+	for _, status := range []string{model.ReleaseCreationStatusPending, model.ReleaseCreationStatusDeploying} {
+		creations, err, _ := db.ListReleaseCreationsByStatus(status)
+		if err != nil {
+			return err
+		}
+		for _, creation := range creations {
+			creation.Status = model.ReleaseCreationStatusFailed
+			creation.Error = "release creation has been interrupted"
+			creation.UpdatedAt = time.Now().Unix()
+			err, _ = db.SetReleaseCreation(creation)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (this *Controller) setReleaseCreationStatus(creation model.SmartServiceReleaseCreation) {
+	creation.UpdatedAt = time.Now().Unix()
+	err, _ := this.db.SetReleaseCreation(creation)
+	if err != nil {
+		this.config.GetLogger().Error("unable to store release creation status", "error", err, "releaseId", creation.ReleaseId, "status", creation.Status)
+	}
+}
+
+func (this *Controller) PreviewRelease(token auth.Token, request model.SmartServiceReleasePreviewRequest, withParameters bool) (result model.SmartServiceReleasePreview, err error, code int) {
+	xml := request.BpmnXml
+	if xml == "" {
+		if request.DesignId == "" {
+			return result, errors.New("missing design id or bpmn xml"), http.StatusBadRequest
+		}
+		design, err, code := this.GetDesign(token, request.DesignId)
+		if err != nil {
+			return result, err, code
+		}
+		xml = design.BpmnXml
+	}
+	result.ParsedInfo, err, code = this.parseAndValidateReleaseInfo(token, xml, model.SmartServiceRelease{DesignId: request.DesignId})
+	if err != nil {
+		return result, err, code
+	}
+	if withParameters {
+		result.Parameters, err, code = this.parameterDescriptionsToSmartServiceExtendedParameter(token, result.ParsedInfo.ParameterDescriptions)
+		if err != nil {
+			return result, err, code
+		}
+	}
+	return result, nil, http.StatusOK
 }
 
 func (this *Controller) saveReleaseCreate(release model.SmartServiceReleaseExtended) (err error) {
@@ -206,12 +350,23 @@ func (this *Controller) deployRelease(release model.SmartServiceReleaseExtended)
 		}
 	}
 
+	if !release.IsReleased() {
+		return nil //deployed by SetReleaseLifecycleState() or ApproveRelease() when published; drafts replace old releases when they are published
+	}
+
 	err, _ = this.camunda.DeployRelease(release.Creator, release)
 	if err != nil {
 		return err
 	}
+	return this.replaceOldReleases(release, oldReleases)
+}
 
+// replaceOldReleases sets NewReleaseId of older releases or deletes them if they are unused
+func (this *Controller) replaceOldReleases(release model.SmartServiceReleaseExtended, oldReleases []model.SmartServiceReleaseExtended) (err error) {
 	for _, old := range oldReleases {
+		if !old.IsReleased() {
+			continue
+		}
 		if old.CreatedAt < release.CreatedAt && old.Id != release.Id { //"if" to prevent race from  HandleReleaseDelete() to recreate deleted release
 			instances, err, _ := this.db.ListInstancesOfRelease("", old.Id)
 			if err != nil {
@@ -234,6 +389,63 @@ func (this *Controller) deployRelease(release model.SmartServiceReleaseExtended)
 	return nil
 }
 
+// PromoteRelease makes the release the latest release of its design by setting NewReleaseId of all other releases of the design to its id.
+// newer releases and their instances are kept; the instances are offered the promoted release as update.
+func (this *Controller) PromoteRelease(token auth.Token, id string) (result model.SmartServiceRelease, err error, code int) {
+	access, err, _ := this.permissions.CheckPermission(token.Jwt(), this.config.SmartServiceReleasePermissionsTopic, id, client.Administrate)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !access {
+		return result, errors.New("access denied"), http.StatusForbidden
+	}
+	release, err, code := this.db.GetRelease(id, false)
+	if err != nil {
+		return result, err, code
+	}
+	if release.GetLifecycleState() != model.ReleaseLifecycleStatePublished {
+		return result, fmt.Errorf("only published releases may be promoted (lifecycle_state = %v)", release.GetLifecycleState()), http.StatusBadRequest
+	}
+	if release.NewReleaseId == "" {
+		return release.SmartServiceRelease, nil, http.StatusOK
+	}
+
+	this.cleanupMux.Lock()
+	defer this.cleanupMux.Unlock()
+
+	deployed, err := this.camunda.IsReleaseDeployed(release.Id)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if !deployed {
+		err, _ = this.camunda.DeployRelease(release.Creator, release)
+		if err != nil {
+			return result, err, http.StatusInternalServerError
+		}
+	}
+
+	release.NewReleaseId = ""
+	err, code = this.db.SetRelease(release, false)
+	if err != nil {
+		return result, err, code
+	}
+	siblings, err := this.getOldReleases(release)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	for _, sibling := range siblings {
+		if !sibling.IsReleased() || sibling.NewReleaseId == release.Id {
+			continue
+		}
+		sibling.NewReleaseId = release.Id
+		err, code = this.db.SetRelease(sibling, false)
+		if err != nil {
+			return result, err, code
+		}
+	}
+	return release.SmartServiceRelease, nil, http.StatusOK
+}
+
 func (this *Controller) GetRelease(token auth.Token, id string) (result model.SmartServiceRelease, err error, code int) {
 	access, err, _ := this.permissions.CheckPermission(token.Jwt(), this.config.SmartServiceReleasePermissionsTopic, id, client.Read)
 	if err != nil {
@@ -244,6 +456,10 @@ func (this *Controller) GetRelease(token auth.Token, id string) (result model.Sm
 	}
 	var extended model.SmartServiceReleaseExtended
 	extended, err, code = this.db.GetRelease(id, false)
+	if err != nil {
+		return extended.SmartServiceRelease, err, code
+	}
+	err, code = checkReleaseVisibility(token, extended.SmartServiceRelease)
 	return extended.SmartServiceRelease, err, code
 }
 
@@ -270,6 +486,10 @@ func (this *Controller) GetExtendedRelease(token auth.Token, id string) (result
 	if err != nil {
 		return result, err, code
 	}
+	err, code = checkReleaseVisibility(token, result.SmartServiceRelease)
+	if err != nil {
+		return result, err, code
+	}
 	result, err = this.ensureValidReleaseModuleInfo(result)
 	if err != nil {
 		return result, err, http.StatusInternalServerError
@@ -278,35 +498,11 @@ func (this *Controller) GetExtendedRelease(token auth.Token, id string) (result
 }
 
 func (this *Controller) ListExtendedReleases(token auth.Token, query model.ReleaseQueryOptions) (result []model.SmartServiceReleaseExtended, total int64, err error, code int) {
-	checkedRigths, err := permmodel.PermissionListFromString(query.Rights)
+	options, err, code := this.getListReleasesOptions(token, query)
 	if err != nil {
-		return result, 0, err, http.StatusBadRequest
+		return result, 0, err, code
 	}
-	listOptions := client.ListOptions{}
-	if len(query.Ids) > 0 {
-		listOptions.Ids = query.Ids
-	}
-	ids, err, _ := this.permissions.ListAccessibleResourceIds(token.Jwt(), this.config.SmartServiceReleasePermissionsTopic, listOptions, checkedRigths...)
-	if err != nil {
-		return result, 0, err, http.StatusInternalServerError
-	}
-	if len(query.Ids) > 0 {
-		ids_t := []string{}
-		for _, id := range query.Ids {
-			if slices.Contains(ids, id) {
-				ids_t = append(ids_t, id)
-			}
-		}
-		ids = ids_t
-	}
-	temp, total, err := this.db.ListReleases(model.ListReleasesOptions{
-		InIds:  ids,
-		Latest: query.Latest,
-		Limit:  query.Limit,
-		Offset: query.Offset,
-		Sort:   query.GetSort(),
-		Search: query.Search,
-	})
+	temp, total, err := this.db.ListReleases(options)
 	if err != nil {
 		return result, 0, err, http.StatusInternalServerError
 	}
@@ -334,6 +530,50 @@ func (this *Controller) ListExtendedReleases(token auth.Token, query model.Relea
 	return result, total, nil, http.StatusOK
 }
 
+// getListReleasesOptions validates query and translates it to model.ListReleasesOptions, limited to the releases accessible by the user
+func (this *Controller) getListReleasesOptions(token auth.Token, query model.ReleaseQueryOptions) (result model.ListReleasesOptions, err error, code int) {
+	checkedRigths, err := permmodel.PermissionListFromString(query.Rights)
+	if err != nil {
+		return result, err, http.StatusBadRequest
+	}
+	switch query.IotType {
+	case "", model.DeviceFilter, model.DeviceServiceGroupFilter, model.GroupFilter, model.ImportFilter:
+	default:
+		return result, fmt.Errorf("unknown iot type %v", query.IotType), http.StatusBadRequest
+	}
+	listOptions := client.ListOptions{}
+	if len(query.Ids) > 0 {
+		listOptions.Ids = query.Ids
+	}
+	ids, err, _ := this.permissions.ListAccessibleResourceIds(token.Jwt(), this.config.SmartServiceReleasePermissionsTopic, listOptions, checkedRigths...)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	if len(query.Ids) > 0 {
+		ids_t := []string{}
+		for _, id := range query.Ids {
+			if slices.Contains(ids, id) {
+				ids_t = append(ids_t, id)
+			}
+		}
+		ids = ids_t
+	}
+	catalogFilter := query.ReleaseCatalogFilter
+	catalogFilter.Category = strings.TrimSpace(catalogFilter.Category)
+	catalogFilter.Tags = normalizeReleaseTags(catalogFilter.Tags)
+	return model.ListReleasesOptions{
+		InIds:                   ids,
+		Latest:                  query.Latest,
+		Limit:                   query.Limit,
+		Offset:                  query.Offset,
+		Sort:                    query.GetSort(),
+		Search:                  query.Search,
+		DraftCreator:            token.GetUserId(),
+		ReleaseCapabilityFilter: query.ReleaseCapabilityFilter,
+		ReleaseCatalogFilter:    catalogFilter,
+	}, nil, http.StatusOK
+}
+
 func computedPermissionsToMap(perm permmodel.ComputedPermissions) map[string]bool {
 	return map[string]bool{
 		"r": perm.Read,
@@ -455,6 +695,10 @@ func (this *Controller) deleteRelease(id string) error {
 		this.config.GetLogger().Warn("db.DeleteRelease() failed but will be retried", "releaseId", id, "error", err)
 		return nil
 	}
+	err, _ = this.db.DeleteReleaseCreation(id)
+	if err != nil {
+		this.config.GetLogger().Warn("unable to delete release creation status", "releaseId", id, "error", err)
+	}
 	return nil
 }
 
@@ -544,3 +788,42 @@ func getSchemaOrgType(t string) model.Type {
 		return model.Type(t)
 	}
 }
+
+// GetReleaseCreation returns the creation status of a release.
+// the status is visible for the creator of the release and users with read access to the release.
+func (this *Controller) GetReleaseCreation(token auth.Token, releaseId string) (result model.SmartServiceReleaseCreation, err error, code int) {
+	result, err, code = this.db.GetReleaseCreation(releaseId)
+	if err != nil && code != http.StatusNotFound {
+		return result, err, code
+	}
+	if err == nil && result.UserId == token.GetUserId() {
+		return result, nil, http.StatusOK
+	}
+	release, err, code := this.GetRelease(token, releaseId)
+	if err != nil {
+		return model.SmartServiceReleaseCreation{}, err, code
+	}
+	if result.ReleaseId == "" {
+		//release created before the creation status existed
+		result = model.SmartServiceReleaseCreation{
+			ReleaseId: release.Id,
+			DesignId:  release.DesignId,
+			Name:      release.Name,
+			UserId:    release.Creator,
+			Status:    model.ReleaseCreationStatusReady,
+			CreatedAt: release.CreatedAt,
+			UpdatedAt: release.CreatedAt,
+		}
+	}
+	return result, nil, http.StatusOK
+}
+
+// ListReleaseCreations lists the release creations started by the user
+func (this *Controller) ListReleaseCreations(token auth.Token, query model.ReleaseCreationQueryOptions) (result []model.SmartServiceReleaseCreation, err error, code int) {
+	switch query.Status {
+	case "", model.ReleaseCreationStatusPending, model.ReleaseCreationStatusDeploying, model.ReleaseCreationStatusReady, model.ReleaseCreationStatusFailed:
+	default:
+		return result, fmt.Errorf("unknown status %v", query.Status), http.StatusBadRequest
+	}
+	return this.db.ListReleaseCreations(token.GetUserId(), query)
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package controller
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+
+	"github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+)
+
+// GetReleaseStats counts the instances of the release and of readable older releases of the same design.
+// only instances readable by the user are counted.
+func (this *Controller) GetReleaseStats(token auth.Token, id string) (result model.SmartServiceReleaseStats, err error, code int) {
+	release, err, code := this.GetRelease(token, id)
+	if err != nil {
+		return result, err, code
+	}
+	siblings, err := this.db.GetReleasesByDesignId(release.DesignId)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	previous := []model.SmartServiceRelease{}
+	previousIds := []string{}
+	for _, sibling := range siblings {
+		if sibling.Id != release.Id && sibling.CreatedAt < release.CreatedAt && sibling.IsReleased() {
+			previous = append(previous, sibling.SmartServiceRelease)
+			previousIds = append(previousIds, sibling.Id)
+		}
+	}
+	if len(previousIds) > 0 {
+		access, err, _ := this.permissions.CheckMultiplePermissions(token.Jwt(), this.config.SmartServiceReleasePermissionsTopic, previousIds, client.Read)
+		if err != nil {
+			return result, err, http.StatusInternalServerError
+		}
+		readable := []model.SmartServiceRelease{}
+		for _, element := range previous {
+			if access[element.Id] {
+				readable = append(readable, element)
+			}
+		}
+		previous = readable
+	}
+	sort.Slice(previous, func(i, j int) bool {
+		return previous[i].CreatedAt > previous[j].CreatedAt
+	})
+
+	releaseIds := []string{release.Id}
+	for _, element := range previous {
+		releaseIds = append(releaseIds, element.Id)
+	}
+	stats, err, code := this.getReleaseInstanceStats(token, releaseIds)
+	if err != nil {
+		return result, err, code
+	}
+	result = model.SmartServiceReleaseStats{
+		ReleaseId:            release.Id,
+		Name:                 release.Name,
+		CreatedAt:            release.CreatedAt,
+		ReleaseInstanceStats: stats[release.Id],
+	}
+	for _, element := range previous {
+		result.PreviousReleases = append(result.PreviousReleases, model.SmartServiceReleaseStats{
+			ReleaseId:            element.Id,
+			Name:                 element.Name,
+			CreatedAt:            element.CreatedAt,
+			ReleaseInstanceStats: stats[element.Id],
+		})
+	}
+	return result, nil, http.StatusOK
+}
+
+// AddReleaseStats sets the Stats field of the releases
+func (this *Controller) AddReleaseStats(token auth.Token, releases []model.SmartServiceRelease) (err error, code int) {
+	releaseIds := []string{}
+	for _, release := range releases {
+		releaseIds = append(releaseIds, release.Id)
+	}
+	stats, err, code := this.getReleaseInstanceStats(token, releaseIds)
+	if err != nil {
+		return err, code
+	}
+	for i, release := range releases {
+		element := stats[release.Id]
+		releases[i].Stats = &element
+	}
+	return nil, http.StatusOK
+}
+
+// releaseInstanceStatsBatchSize limits the instance ids per permission check and stats query
+const releaseInstanceStatsBatchSize = 1000
+
+func (this *Controller) getReleaseInstanceStats(token auth.Token, releaseIds []string) (result map[string]model.ReleaseInstanceStats, err error, code int) {
+	if len(releaseIds) == 0 {
+		return map[string]model.ReleaseInstanceStats{}, nil, http.StatusOK
+	}
+	if token.IsAdmin() {
+		//admins may read every instance
+		return this.db.GetReleaseInstanceStats(releaseIds, nil)
+	}
+	//only the instances of the releases are checked, instead of every instance readable by the user
+	instanceIds, err, code := this.db.ListInstanceIdsOfReleases(releaseIds)
+	if err != nil {
+		return result, err, code
+	}
+	result = map[string]model.ReleaseInstanceStats{}
+	for batch := range slices.Chunk(instanceIds, releaseInstanceStatsBatchSize) {
+		accessible, err, code := this.permissions.ListAccessibleResourceIds(token.Token, this.config.SmartServiceInstancePermissionsTopic, client.ListOptions{Ids: batch}, client.Read)
+		if err != nil {
+			return result, err, code
+		}
+		if len(accessible) == 0 {
+			continue
+		}
+		stats, err, code := this.db.GetReleaseInstanceStats(releaseIds, accessible)
+		if err != nil {
+			return result, err, code
+		}
+		for releaseId, element := range stats {
+			sum := result[releaseId]
+			sum.Instances += element.Instances
+			sum.Ready += element.Ready
+			sum.Error += element.Error
+			sum.Outdated += element.Outdated
+			result[releaseId] = sum
+		}
+	}
+	return result, nil, http.StatusOK
+}
@@ -22,9 +22,11 @@ import (
 	"runtime/debug"
 
 	"github.com/SENERGY-Platform/smart-service-repository/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
 	"github.com/beevik/etree"
 )
 
+// ValidateDesign returns the first error found by validateStartEvents
 func ValidateDesign(config configuration.Config, xml string) (err error) {
 	defer func() {
 		if r := recover(); r != nil && err == nil {
@@ -37,32 +39,64 @@ func ValidateDesign(config configuration.Config, xml string) (err error) {
 	if err != nil {
 		return err
 	}
-	err = validateStartEvents(doc)
-	if err != nil {
-		return err
-	}
-	return nil
+	diagnostics := &designDiagnostics{}
+	validateStartEvents(doc, diagnostics)
+	return diagnostics.err()
 }
 
-func validateStartEvents(doc *etree.Document) (err error) {
+func validateStartEvents(doc *etree.Document, diagnostics *designDiagnostics) {
 	startEvents := doc.FindElements("//bpmn:startEvent")
 	hasDefaultStart := false
 	for _, startEvent := range startEvents {
+		id := startEvent.SelectAttrValue("id", "")
 		msgEvent := startEvent.FindElement(".//bpmn:messageEventDefinition")
 		if msgEvent != nil && msgEvent.SelectAttr("messageRef") == nil {
-			return fmt.Errorf("missing message event name")
+			diagnostics.errorf("missing_message_ref", id, "", "missing message event name")
 		} else if startEvent.FindElement(".//bpmn:conditionalEventDefinition") != nil {
-			return fmt.Errorf("conditional start-events are not allowed")
+			diagnostics.errorf("conditional_start_event", id, "", "conditional start-events are not allowed")
 		} else if startEvent.FindElement(".//bpmn:signalEventDefinition") != nil {
-			return fmt.Errorf("signal start-events are not allowed")
+			diagnostics.errorf("signal_start_event", id, "", "signal start-events are not allowed")
 		} else if startEvent.FindElement(".//bpmn:timerEventDefinition") != nil {
-			return fmt.Errorf("time start-events are not allowed")
+			diagnostics.errorf("timer_start_event", id, "", "time start-events are not allowed")
 		} else {
 			hasDefaultStart = true
 		}
 	}
 	if !hasDefaultStart {
-		return fmt.Errorf("missing default start-event")
+		diagnostics.errorf("missing_default_start_event", "", "", "missing default start-event")
+	}
+}
+
+// designDiagnostics collects every problem found in a design.
+// ValidateDesign and CreateRelease use the first error, LintDesign reports all of them.
+type designDiagnostics struct {
+	list []model.DesignDiagnostic
+}
+
+func (this *designDiagnostics) errorf(code string, elementId string, formFieldId string, format string, args ...interface{}) {
+	this.add(model.DesignDiagnosticSeverityError, code, elementId, formFieldId, fmt.Sprintf(format, args...))
+}
+
+func (this *designDiagnostics) warnf(code string, elementId string, formFieldId string, format string, args ...interface{}) {
+	this.add(model.DesignDiagnosticSeverityWarning, code, elementId, formFieldId, fmt.Sprintf(format, args...))
+}
+
+func (this *designDiagnostics) add(severity string, code string, elementId string, formFieldId string, message string) {
+	this.list = append(this.list, model.DesignDiagnostic{
+		Severity:    severity,
+		Code:        code,
+		ElementId:   elementId,
+		FormFieldId: formFieldId,
+		Message:     message,
+	})
+}
+
+// err returns the first error diagnostic as error; warnings are ignored
+func (this *designDiagnostics) err() error {
+	for _, diagnostic := range this.list {
+		if diagnostic.Severity == model.DesignDiagnosticSeverityError {
+			return errors.New(diagnostic.Message)
+		}
 	}
 	return nil
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/SENERGY-Platform/smart-service-repository/pkg/auth"
 	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
 	"net/http"
+	"sort"
 )
 
 func (this *Controller) SetVariable(token auth.Token, variable model.SmartServiceInstanceVariable) (result model.SmartServiceInstanceVariable, err error, code int) {
@@ -48,7 +49,17 @@ func (this *Controller) SetVariable(token auth.Token, variable model.SmartServic
 	if err != nil {
 		return result, err, code
 	}
-	return this.db.SetVariable(variable)
+	result, err, code = this.db.SetVariable(variable)
+	if err != nil {
+		return result, err, code
+	}
+	this.addInstanceEvent(model.SmartServiceInstanceEvent{
+		InstanceId:    variable.InstanceId,
+		Type:          model.InstanceEventVariablesSet,
+		Actor:         userActor(token),
+		VariableNames: []string{variable.Name},
+	})
+	return result, nil, code
 }
 
 func (this *Controller) SetVariableForProcessInstance(processInstanceId string, element model.SmartServiceInstanceVariable) (result model.SmartServiceInstanceVariable, err error, code int) {
@@ -65,7 +76,17 @@ func (this *Controller) SetVariableForProcessInstance(processInstanceId string,
 	}
 	element.InstanceId = instance.Id
 	element.UserId = instance.UserId
-	return this.db.SetVariable(element)
+	result, err, code = this.db.SetVariable(element)
+	if err != nil {
+		return result, err, code
+	}
+	this.addInstanceEvent(model.SmartServiceInstanceEvent{
+		InstanceId:    instance.Id,
+		Type:          model.InstanceEventVariablesSet,
+		Actor:         processInstanceActor(processInstanceId),
+		VariableNames: []string{element.Name},
+	})
+	return result, nil, code
 }
 
 func (this *Controller) SetVariablesMapOfProcessInstance(processInstanceId string, mappedVariableValues map[string]interface{}) (err error, code int) {
@@ -80,6 +101,7 @@ func (this *Controller) SetVariablesMapOfProcessInstance(processInstanceId strin
 	if err != nil {
 		return err, code
 	}
+	names := []string{}
 	for key, value := range mappedVariableValues {
 		_, err, code = this.db.SetVariable(model.SmartServiceInstanceVariable{
 			InstanceId: instance.Id,
@@ -90,6 +112,16 @@ func (this *Controller) SetVariablesMapOfProcessInstance(processInstanceId strin
 		if err != nil {
 			return err, code
 		}
+		names = append(names, key)
+	}
+	if len(names) > 0 {
+		sort.Strings(names)
+		this.addInstanceEvent(model.SmartServiceInstanceEvent{
+			InstanceId:    instance.Id,
+			Type:          model.InstanceEventVariablesSet,
+			Actor:         processInstanceActor(processInstanceId),
+			VariableNames: names,
+		})
 	}
 	return
 }
@@ -166,7 +198,17 @@ func (this *Controller) DeleteVariable(token auth.Token, instanceId string, name
 	if !access {
 		return errors.New("missing instance administrate access"), http.StatusForbidden
 	}
-	return this.db.DeleteVariable(instanceId, "", name)
+	err, code = this.db.DeleteVariable(instanceId, "", name)
+	if err != nil {
+		return err, code
+	}
+	this.addInstanceEvent(model.SmartServiceInstanceEvent{
+		InstanceId:    instanceId,
+		Type:          model.InstanceEventVariableDeleted,
+		Actor:         userActor(token),
+		VariableNames: []string{name},
+	})
+	return nil, code
 }
 
 func (this *Controller) GetVariable(token auth.Token, instanceId string, name string) (model.SmartServiceInstanceVariable, error, int) {
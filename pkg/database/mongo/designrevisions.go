@@ -0,0 +1,109 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"net/http"
+	"runtime/debug"
+)
+
+var DesignRevisionBson = getBsonFieldObject[model.SmartServiceDesignRevision]()
+
+var ErrDesignRevisionNotFound = errors.New("design revision not found")
+var ErrDesignRevisionConflict = errors.New("design revision already exists (concurrent update)")
+
+func init() {
+	CreateCollections = append(CreateCollections, func(db *Mongo) error {
+		var err error
+		collection := db.client.Database(db.config.MongoTable).Collection(db.config.MongoCollectionDesignRevisions)
+		err = db.ensureCompoundIndex(collection, "design_revision_index", true, true, DesignRevisionBson.Id, "revision")
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		return nil
+	})
+}
+
+func (this *Mongo) designRevisionCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoCollectionDesignRevisions)
+}
+
+func (this *Mongo) AddDesignRevision(element model.SmartServiceDesignRevision) (error, int) {
+	ctx, _ := getTimeoutContext()
+	_, err := this.designRevisionCollection().InsertOne(ctx, element)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrDesignRevisionConflict, http.StatusConflict
+	}
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	return nil, http.StatusOK
+}
+
+func (this *Mongo) GetDesignRevision(designId string, revision int64) (result model.SmartServiceDesignRevision, err error, code int) {
+	ctx, _ := getTimeoutContext()
+	temp := this.designRevisionCollection().FindOne(ctx, bson.M{DesignRevisionBson.Id: designId, "revision": revision})
+	err = temp.Err()
+	if err == mongo.ErrNoDocuments {
+		return result, ErrDesignRevisionNotFound, http.StatusNotFound
+	}
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	err = temp.Decode(&result)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Mongo) ListDesignRevisions(designId string, query model.DesignRevisionQueryOptions) (result []model.SmartServiceDesignRevision, err error, code int) {
+	opt := createFindOptions(query)
+	ctx, _ := getTimeoutContext()
+	cursor, err := this.designRevisionCollection().Find(ctx, bson.M{DesignRevisionBson.Id: designId}, opt)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	defer cursor.Close(context.Background())
+	return readCursorResult[model.SmartServiceDesignRevision](ctx, cursor)
+}
+
+func (this *Mongo) DeleteDesignRevisions(designId string) (error, int) {
+	ctx, _ := getTimeoutContext()
+	_, err := this.designRevisionCollection().DeleteMany(ctx, bson.M{DesignRevisionBson.Id: designId})
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	return nil, http.StatusOK
+}
+
+// DeleteDesignRevision removes a single revision; used to remove the revision of a failed design update
+func (this *Mongo) DeleteDesignRevision(designId string, revision int64) (error, int) {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	_, err := this.designRevisionCollection().DeleteOne(ctx, bson.M{DesignRevisionBson.Id: designId, "revision": revision})
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	return nil, http.StatusOK
+}
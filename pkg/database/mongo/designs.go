@@ -30,6 +30,7 @@ import (
 var DesignBson = getBsonFieldObject[model.SmartServiceDesign]()
 
 var ErrDesignNotFound = errors.New("design not found")
+var ErrDesignPreconditionFailed = errors.New("design has been changed or removed since the given revision")
 
 func init() {
 	CreateCollections = append(CreateCollections, func(db *Mongo) error {
@@ -41,16 +42,34 @@ func init() {
 			return err
 		}
 
+		err = db.ensureIndex(collection, "design_id_index", DesignBson.Id, true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+
+		err = db.ensureIndex(collection, "design_template_index", "template", true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+
+		//designs are no longer filtered by user_id if searched (shared designs) --> replace user compound text index
 		ctx, _ := getTimeoutContext()
-		_, err = collection.Indexes().CreateOne(ctx, mongo.IndexModel{
-			Keys: bson.D([]bson.E{
-				{Key: DesignBson.UserId, Value: 1},
-				{Key: DesignBson.Name, Value: "text"},
-				{Key: DesignBson.Description, Value: "text"},
-			}),
-			Options: options.Index().SetName("design_search_with_user_compound_index"),
-		})
-		return err
+		_, err = collection.Indexes().DropOne(ctx, "design_search_with_user_compound_index")
+		if err != nil {
+			var cmdErr mongo.CommandError
+			if !errors.As(err, &cmdErr) || cmdErr.Name != "IndexNotFound" {
+				debug.PrintStack()
+				return err
+			}
+		}
+		err = db.ensureTextIndex(collection, "design_search_index", DesignBson.Name, DesignBson.Description)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		return nil
 	})
 }
 
@@ -60,7 +79,11 @@ func (this *Mongo) designCollection() *mongo.Collection {
 
 func (this *Mongo) GetDesign(id string, userId string) (result model.SmartServiceDesign, err error, code int) {
 	ctx, _ := getTimeoutContext()
-	temp := this.designCollection().FindOne(ctx, bson.M{DesignBson.Id: id, DesignBson.UserId: userId})
+	filter := bson.M{DesignBson.Id: id}
+	if userId != "" {
+		filter[DesignBson.UserId] = userId
+	}
+	temp := this.designCollection().FindOne(ctx, filter)
 	err = temp.Err()
 	if err == mongo.ErrNoDocuments {
 		return result, ErrDesignNotFound, http.StatusNotFound
@@ -80,8 +103,7 @@ func (this *Mongo) SetDesign(element model.SmartServiceDesign) (error, int) {
 	_, err := this.designCollection().ReplaceOne(
 		ctx,
 		bson.M{
-			DesignBson.Id:     element.Id,
-			DesignBson.UserId: element.UserId,
+			DesignBson.Id: element.Id,
 		},
 		element,
 		options.Replace().SetUpsert(true))
@@ -91,12 +113,35 @@ func (this *Mongo) SetDesign(element model.SmartServiceDesign) (error, int) {
 	return nil, http.StatusOK
 }
 
+// SetDesignIfRevision replaces the design only if the stored design still has the expected revision
+func (this *Mongo) SetDesignIfRevision(element model.SmartServiceDesign, expectedRevision int64) (error, int) {
+	filter := bson.M{
+		DesignBson.Id: element.Id,
+		"revision":    expectedRevision,
+	}
+	if expectedRevision == 0 {
+		//designs stored before revisions existed have no revision field
+		delete(filter, "revision")
+		filter["$or"] = []bson.M{{"revision": 0}, {"revision": bson.M{"$exists": false}}}
+	}
+	ctx, _ := getTimeoutContext()
+	result, err := this.designCollection().ReplaceOne(ctx, filter, element)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	if result.MatchedCount == 0 {
+		return ErrDesignPreconditionFailed, http.StatusPreconditionFailed
+	}
+	return nil, http.StatusOK
+}
+
 func (this *Mongo) DeleteDesign(id string, userId string) (error, int) {
 	ctx, _ := getTimeoutContext()
-	_, err := this.designCollection().DeleteMany(ctx, bson.M{
-		DesignBson.Id:     id,
-		DesignBson.UserId: userId,
-	})
+	filter := bson.M{DesignBson.Id: id}
+	if userId != "" {
+		filter[DesignBson.UserId] = userId
+	}
+	_, err := this.designCollection().DeleteMany(ctx, filter)
 	if err != nil {
 		return err, http.StatusInternalServerError
 	}
@@ -106,7 +151,24 @@ func (this *Mongo) DeleteDesign(id string, userId string) (error, int) {
 func (this *Mongo) ListDesigns(userId string, query model.DesignQueryOptions) (result []model.SmartServiceDesign, err error, code int) {
 	opt := createFindOptions(query)
 	ctx, _ := getTimeoutContext()
-	filter := bson.M{DesignBson.UserId: userId}
+	filter := bson.M{}
+	if userId != "" {
+		filter[DesignBson.UserId] = userId
+	}
+	if query.Ids != nil {
+		filter[DesignBson.Id] = bson.M{"$in": query.Ids}
+	}
+	if query.Template != nil {
+		if *query.Template {
+			filter["template"] = true
+		} else {
+			filter["template"] = bson.M{"$ne": true} //designs stored before the template flag existed have no template field
+			if query.UserId != "" {
+				delete(filter, "template")
+				filter["$or"] = []bson.M{{"template": bson.M{"$ne": true}}, {DesignBson.UserId: query.UserId}}
+			}
+		}
+	}
 	if query.Search != "" {
 		filter["$text"] = bson.M{"$search": query.Search}
 	}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"context"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var InstanceEventBson = getBsonFieldObject[model.SmartServiceInstanceEvent]()
+
+const InstanceEventBsonTime = "time"
+
+func init() {
+	CreateCollections = append(CreateCollections, func(db *Mongo) error {
+		var err error
+		collection := db.client.Database(db.config.MongoTable).Collection(db.config.MongoCollectionInstanceHistory)
+		err = db.ensureIndex(collection, "instance_event_id_index", InstanceEventBson.Id, true, true)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureCompoundIndex(collection, "instance_event_instance_time_index", true, false, InstanceEventBson.InstanceId, InstanceEventBsonTime)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		return nil
+	})
+}
+
+func (this *Mongo) instanceHistoryCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoCollectionInstanceHistory)
+}
+
+// AddInstanceEvent appends an event to the history of an instance; events are never updated
+func (this *Mongo) AddInstanceEvent(element model.SmartServiceInstanceEvent) (error, int) {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	_, err := this.instanceHistoryCollection().InsertOne(ctx, element)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	return nil, http.StatusOK
+}
+
+func (this *Mongo) ListInstanceEvents(instanceId string, query model.InstanceEventQueryOptions) (result []model.SmartServiceInstanceEvent, total int64, err error, code int) {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	filter := bson.M{InstanceEventBson.InstanceId: instanceId}
+	cursor, err := this.instanceHistoryCollection().Find(ctx, filter, createFindOptions(query))
+	if err != nil {
+		return result, total, err, http.StatusInternalServerError
+	}
+	defer cursor.Close(context.Background())
+	result, err, code = readCursorResult[model.SmartServiceInstanceEvent](ctx, cursor)
+	if err != nil {
+		return result, total, err, code
+	}
+	total, err = this.instanceHistoryCollection().CountDocuments(ctx, filter)
+	if err != nil {
+		return result, total, err, http.StatusInternalServerError
+	}
+	return result, total, nil, http.StatusOK
+}
+
+// DeleteInstanceEvents removes the history of an instance; used for test instances
+func (this *Mongo) DeleteInstanceEvents(instanceId string) (error, int) {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	_, err := this.instanceHistoryCollection().DeleteMany(ctx, bson.M{InstanceEventBson.InstanceId: instanceId})
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	return nil, http.StatusOK
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var InstanceMigrationJobBson = getBsonFieldObject[model.InstanceMigrationJob]()
+
+var ErrInstanceMigrationJobNotFound = errors.New("instance migration job not found")
+
+func init() {
+	CreateCollections = append(CreateCollections, func(db *Mongo) error {
+		var err error
+		collection := db.client.Database(db.config.MongoTable).Collection(db.config.MongoCollectionInstanceMigrations)
+		err = db.ensureIndex(collection, "instance_migration_id_index", InstanceMigrationJobBson.Id, true, true)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "instance_migration_release_index", InstanceMigrationJobBson.ReleaseId, true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "instance_migration_status_index", InstanceMigrationJobBson.Status, true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		return nil
+	})
+}
+
+func (this *Mongo) instanceMigrationCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoCollectionInstanceMigrations)
+}
+
+func (this *Mongo) SetInstanceMigrationJob(element model.InstanceMigrationJob) (error, int) {
+	ctx, _ := getTimeoutContext()
+	_, err := this.instanceMigrationCollection().ReplaceOne(ctx, bson.M{InstanceMigrationJobBson.Id: element.Id}, element, options.Replace().SetUpsert(true))
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	return nil, http.StatusOK
+}
+
+func (this *Mongo) GetInstanceMigrationJob(id string) (result model.InstanceMigrationJob, err error, code int) {
+	ctx, _ := getTimeoutContext()
+	temp := this.instanceMigrationCollection().FindOne(ctx, bson.M{InstanceMigrationJobBson.Id: id})
+	err = temp.Err()
+	if err == mongo.ErrNoDocuments {
+		return result, ErrInstanceMigrationJobNotFound, http.StatusNotFound
+	}
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	err = temp.Decode(&result)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return result, nil, http.StatusOK
+}
+
+// ListInstanceMigrationJobs returns the jobs of a release, newest first
+func (this *Mongo) ListInstanceMigrationJobs(releaseId string) (result []model.InstanceMigrationJob, err error, code int) {
+	ctx, _ := getTimeoutContext()
+	cursor, err := this.instanceMigrationCollection().Find(ctx, bson.M{InstanceMigrationJobBson.ReleaseId: releaseId}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	defer cursor.Close(context.Background())
+	return readCursorResult[model.InstanceMigrationJob](ctx, cursor)
+}
+
+func (this *Mongo) ListInstanceMigrationJobsByStatus(status string) (result []model.InstanceMigrationJob, err error, code int) {
+	ctx, _ := getTimeoutContext()
+	cursor, err := this.instanceMigrationCollection().Find(ctx, bson.M{InstanceMigrationJobBson.Status: status})
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	defer cursor.Close(context.Background())
+	return readCursorResult[model.InstanceMigrationJob](ctx, cursor)
+}
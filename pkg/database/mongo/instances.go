@@ -32,7 +32,16 @@ import (
 
 var InstanceBson = getBsonFieldObject[model.SmartServiceInstance]()
 
+const InstanceBsonTest = "test"
+const InstanceBsonExpiresAt = "expires_at"
+const InstanceBsonStateChangedAt = "state_changed_at"
+const InstanceBsonReady = "ready"
+const InstanceBsonDeleting = "deleting"
+const InstanceBsonCreatedAt = "created_at"
+const InstanceBsonUpdatedAt = "updated_at"
+
 var ErrInstanceNotFound = errors.New("instance not found")
+var ErrInstancePreconditionFailed = errors.New("instance has been changed or removed since the given updated_at")
 
 func init() {
 	CreateCollections = append(CreateCollections, func(db *Mongo) error {
@@ -58,6 +67,46 @@ func init() {
 			debug.PrintStack()
 			return err
 		}
+		err = db.ensureIndex(collection, "instance_new_release_index", InstanceBson.NewReleaseId, true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureCompoundIndex(collection, "instance_test_expiration_index", true, false, InstanceBsonTest, InstanceBsonExpiresAt)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureCompoundIndex(collection, "instance_state_index", true, false, InstanceBson.State, InstanceBsonStateChangedAt)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "instance_design_index", InstanceBson.DesignId, true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "instance_name_index", InstanceBson.Name, true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "instance_created_at_index", InstanceBsonCreatedAt, true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "instance_updated_at_index", InstanceBsonUpdatedAt, true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureTextIndex(collection, "instance_search_index", InstanceBson.Name, InstanceBson.Description)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
 		return nil
 	})
 }
@@ -97,22 +146,72 @@ func (this *Mongo) GetInstance(id string, userId string) (result model.SmartServ
 	return result, nil, http.StatusOK
 }
 
+// SetInstance stores the instance; state and state_changed_at of existing instances are only changed by SetInstanceState
 func (this *Mongo) SetInstance(element model.SmartServiceInstance) (error, int) {
+	update, err := getInstanceUpdateWithoutState(element)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	ctx, _ := getTimeoutContext()
+	_, err = this.instanceCollection().UpdateOne(
+		ctx,
+		bson.M{
+			InstanceBson.Id:     element.Id,
+			InstanceBson.UserId: element.UserId,
+		},
+		update,
+		options.Update().SetUpsert(true))
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	return nil, http.StatusOK
+}
+
+// SetInstanceIfUpdatedAt updates the instance only if the stored instance still has the expected updated_at value
+func (this *Mongo) SetInstanceIfUpdatedAt(element model.SmartServiceInstance, expectedUpdatedAt int64) (error, int) {
+	update, err := getInstanceUpdateWithoutState(element)
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
 	ctx, _ := getTimeoutContext()
-	_, err := this.instanceCollection().ReplaceOne(
+	result, err := this.instanceCollection().UpdateOne(
 		ctx,
 		bson.M{
 			InstanceBson.Id:     element.Id,
 			InstanceBson.UserId: element.UserId,
+			"updated_at":        expectedUpdatedAt,
 		},
-		element,
-		options.Replace().SetUpsert(true))
+		update)
 	if err != nil {
 		return err, http.StatusInternalServerError
 	}
+	if result.MatchedCount == 0 {
+		return ErrInstancePreconditionFailed, http.StatusPreconditionFailed
+	}
 	return nil, http.StatusOK
 }
 
+// getInstanceUpdateWithoutState sets every field except the state, which is only set if the instance is inserted.
+// this prevents full writes from overwriting concurrent state transitions of SetInstanceState.
+func getInstanceUpdateWithoutState(element model.SmartServiceInstance) (bson.M, error) {
+	b, err := bson.Marshal(element)
+	if err != nil {
+		return nil, err
+	}
+	set := bson.M{}
+	err = bson.Unmarshal(b, &set)
+	if err != nil {
+		return nil, err
+	}
+	delete(set, "_id")
+	delete(set, InstanceBson.State)
+	delete(set, InstanceBsonStateChangedAt)
+	return bson.M{
+		"$set":         set,
+		"$setOnInsert": bson.M{InstanceBson.State: element.State, InstanceBsonStateChangedAt: element.StateChangedAt},
+	}, nil
+}
+
 func (this *Mongo) DeleteInstance(id string, userId string) (err error, code int) {
 	err, code = this.RemoveModulesOfInstance(id, userId)
 	if err != nil {
@@ -149,6 +248,20 @@ func (this *Mongo) ListInstances(userId string, query model.InstanceQueryOptions
 	if len(query.IDs) > 0 {
 		filter[InstanceBson.Id] = bson.M{"$in": query.IDs}
 	}
+	if query.Test != nil {
+		if *query.Test {
+			filter[InstanceBsonTest] = true
+		} else {
+			filter[InstanceBsonTest] = bson.M{"$ne": true}
+		}
+	}
+	if len(query.States) > 0 {
+		filter[InstanceBson.State] = bson.M{"$in": query.States}
+	}
+	if query.StateChangedBefore > 0 {
+		filter[InstanceBsonStateChangedAt] = bson.M{"$lt": query.StateChangedBefore}
+	}
+	addInstanceSearchFilter(filter, query)
 	cursor, err := this.instanceCollection().Find(ctx, filter, opt)
 	if err != nil {
 		return result, total, err, http.StatusInternalServerError
@@ -169,6 +282,67 @@ func (this *Mongo) ListInstances(userId string, query model.InstanceQueryOptions
 	return result, total, err, code
 }
 
+// addInstanceSearchFilter adds the search, flag, time range and owner options of query to filter
+func addInstanceSearchFilter(filter bson.M, query model.InstanceQueryOptions) {
+	if query.Search != "" {
+		filter["$text"] = bson.M{"$search": query.Search}
+	}
+	if query.DesignId != "" {
+		filter[InstanceBson.DesignId] = query.DesignId
+	}
+	if query.Ready != nil {
+		filter[InstanceBsonReady] = *query.Ready
+	}
+	if query.Deleting != nil {
+		if *query.Deleting {
+			filter[InstanceBsonDeleting] = true
+		} else {
+			filter[InstanceBsonDeleting] = bson.M{"$ne": true}
+		}
+	}
+	if query.HasError != nil {
+		if *query.HasError {
+			filter[InstanceBson.Error] = bson.M{"$nin": bson.A{nil, ""}}
+		} else {
+			filter[InstanceBson.Error] = bson.M{"$in": bson.A{nil, ""}}
+		}
+	}
+	if query.OutdatedRelease != nil {
+		if *query.OutdatedRelease {
+			filter[InstanceBson.NewReleaseId] = bson.M{"$nin": bson.A{nil, ""}}
+		} else {
+			filter[InstanceBson.NewReleaseId] = bson.M{"$in": bson.A{nil, ""}}
+		}
+	}
+	createdAt := bson.M{}
+	if query.CreatedAfter > 0 {
+		createdAt["$gt"] = query.CreatedAfter
+	}
+	if query.CreatedBefore > 0 {
+		createdAt["$lt"] = query.CreatedBefore
+	}
+	if len(createdAt) > 0 {
+		filter[InstanceBsonCreatedAt] = createdAt
+	}
+	updatedAt := bson.M{}
+	if query.UpdatedAfter > 0 {
+		updatedAt["$gt"] = query.UpdatedAfter
+	}
+	if query.UpdatedBefore > 0 {
+		updatedAt["$lt"] = query.UpdatedBefore
+	}
+	if len(updatedAt) > 0 {
+		filter[InstanceBsonUpdatedAt] = updatedAt
+	}
+	if query.Shared != nil {
+		if *query.Shared {
+			filter[InstanceBson.UserId] = bson.M{"$ne": query.UserId}
+		} else {
+			filter[InstanceBson.UserId] = query.UserId
+		}
+	}
+}
+
 func (this *Mongo) SetInstanceError(id string, userId string, errMsg string) error {
 	ctx, _ := getTimeoutContext()
 	_, err := this.instanceCollection().UpdateOne(ctx, bson.M{
@@ -206,6 +380,47 @@ func (this *Mongo) ListInstancesOfRelease(userId string, releaseId string) (resu
 	return result, nil, http.StatusOK
 }
 
+// SetInstanceState updates state and state_changed_at without changing updated_at;
+// the update is skipped if the stored state is not expectedState (empty expectedState matches instances without state)
+func (this *Mongo) SetInstanceState(id string, expectedState string, state string, stateChangedAt int64) error {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	filter := bson.M{InstanceBson.Id: id, InstanceBson.State: expectedState}
+	if expectedState == "" {
+		filter[InstanceBson.State] = bson.M{"$in": bson.A{nil, ""}}
+	}
+	_, err := this.instanceCollection().UpdateOne(ctx, filter, bson.M{
+		"$set": bson.M{InstanceBson.State: state, InstanceBsonStateChangedAt: stateChangedAt},
+	})
+	return err
+}
+
+// ListExpiredTestInstances returns test instances with an expires_at before the given unix timestamp
+func (this *Mongo) ListExpiredTestInstances(before int64) (result []model.SmartServiceInstance, err error, code int) {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	cursor, err := this.instanceCollection().Find(ctx, bson.M{
+		InstanceBsonTest:      true,
+		InstanceBsonExpiresAt: bson.M{"$lt": before},
+	})
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	defer cursor.Close(context.Background())
+	return readCursorResult[model.SmartServiceInstance](ctx, cursor)
+}
+
+// ListInstancesOfNewRelease returns instances which may be updated to the given release (NewReleaseId == releaseId)
+func (this *Mongo) ListInstancesOfNewRelease(releaseId string) (result []model.SmartServiceInstance, err error, code int) {
+	ctx, _ := getTimeoutContext()
+	cursor, err := this.instanceCollection().Find(ctx, bson.M{InstanceBson.NewReleaseId: releaseId})
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	defer cursor.Close(context.Background())
+	return readCursorResult[model.SmartServiceInstance](ctx, cursor)
+}
+
 func (this *Mongo) AddModuleErrorToInstance(userId string, instance model.SmartServiceInstance) (model.SmartServiceInstance, error) {
 	list, err := this.AddModuleErrorToInstances(userId, []model.SmartServiceInstance{instance})
 	if err != nil {
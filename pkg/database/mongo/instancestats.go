@@ -0,0 +1,105 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type releaseInstanceStatsAggregation struct {
+	ReleaseId string `bson:"_id"`
+	Instances int64  `bson:"instances"`
+	Ready     int64  `bson:"ready"`
+	Error     int64  `bson:"error"`
+	Outdated  int64  `bson:"outdated"`
+}
+
+// ListInstanceIdsOfReleases returns the ids of all instances of the given releases
+func (this *Mongo) ListInstanceIdsOfReleases(releaseIds []string) (result []string, err error, code int) {
+	result = []string{}
+	if len(releaseIds) == 0 {
+		return result, nil, http.StatusOK
+	}
+	ctx, _ := getTimeoutContext()
+	cursor, err := this.instanceCollection().Find(ctx, bson.M{InstanceBson.ReleaseId: bson.M{"$in": releaseIds}}, options.Find().SetProjection(bson.M{InstanceBson.Id: 1}))
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	defer cursor.Close(context.Background())
+	list, err, code := readCursorResult[instanceIdProjection](ctx, cursor)
+	if err != nil {
+		return result, err, code
+	}
+	for _, element := range list {
+		result = append(result, element.Id)
+	}
+	return result, nil, http.StatusOK
+}
+
+type instanceIdProjection struct {
+	Id string `bson:"id"`
+}
+
+// GetReleaseInstanceStats counts the instances of the given releases, grouped by release id.
+// if instanceIds is nil, every instance of the releases is counted; otherwise only instances with an id in instanceIds are counted.
+func (this *Mongo) GetReleaseInstanceStats(releaseIds []string, instanceIds []string) (result map[string]model.ReleaseInstanceStats, err error, code int) {
+	result = map[string]model.ReleaseInstanceStats{}
+	if len(releaseIds) == 0 || (instanceIds != nil && len(instanceIds) == 0) {
+		return result, nil, http.StatusOK
+	}
+	notEmpty := func(field string) bson.M {
+		return bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{bson.M{"$ifNull": bson.A{"$" + field, ""}}, ""}}, 1, 0}}
+	}
+	filter := bson.M{InstanceBson.ReleaseId: bson.M{"$in": releaseIds}}
+	if instanceIds != nil {
+		filter[InstanceBson.Id] = bson.M{"$in": instanceIds}
+	}
+	pipeline := bson.A{
+		bson.M{"$match": filter},
+		bson.M{"$group": bson.M{
+			"_id":       "$" + InstanceBson.ReleaseId,
+			"instances": bson.M{"$sum": 1},
+			"ready":     bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$ready", true}}, 1, 0}}},
+			"error":     bson.M{"$sum": notEmpty(InstanceBson.Error)},
+			"outdated":  bson.M{"$sum": notEmpty(InstanceBson.NewReleaseId)},
+		}},
+	}
+	ctx, _ := getTimeoutContext()
+	cursor, err := this.instanceCollection().Aggregate(ctx, pipeline)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	defer cursor.Close(context.Background())
+	list, err, code := readCursorResult[releaseInstanceStatsAggregation](ctx, cursor)
+	if err != nil {
+		return result, err, code
+	}
+	for _, element := range list {
+		result[element.ReleaseId] = model.ReleaseInstanceStats{
+			Instances: element.Instances,
+			Ready:     element.Ready,
+			Error:     element.Error,
+			Outdated:  element.Outdated,
+		}
+	}
+	return result, nil, http.StatusOK
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mongo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ReleaseCreationBson = getBsonFieldObject[model.SmartServiceReleaseCreation]()
+
+var ErrReleaseCreationNotFound = errors.New("release creation not found")
+
+func init() {
+	CreateCollections = append(CreateCollections, func(db *Mongo) error {
+		var err error
+		collection := db.client.Database(db.config.MongoTable).Collection(db.config.MongoCollectionReleaseCreations)
+		err = db.ensureIndex(collection, "release_creation_release_index", ReleaseCreationBson.ReleaseId, true, true)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureCompoundIndex(collection, "release_creation_user_index", true, false, ReleaseCreationBson.UserId, "created_at")
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "release_creation_status_index", ReleaseCreationBson.Status, true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		return nil
+	})
+}
+
+func (this *Mongo) releaseCreationCollection() *mongo.Collection {
+	return this.client.Database(this.config.MongoTable).Collection(this.config.MongoCollectionReleaseCreations)
+}
+
+func (this *Mongo) SetReleaseCreation(element model.SmartServiceReleaseCreation) (error, int) {
+	ctx, _ := getTimeoutContext()
+	_, err := this.releaseCreationCollection().ReplaceOne(ctx, bson.M{ReleaseCreationBson.ReleaseId: element.ReleaseId}, element, options.Replace().SetUpsert(true))
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	return nil, http.StatusOK
+}
+
+func (this *Mongo) GetReleaseCreation(releaseId string) (result model.SmartServiceReleaseCreation, err error, code int) {
+	ctx, _ := getTimeoutContext()
+	temp := this.releaseCreationCollection().FindOne(ctx, bson.M{ReleaseCreationBson.ReleaseId: releaseId})
+	err = temp.Err()
+	if err == mongo.ErrNoDocuments {
+		return result, ErrReleaseCreationNotFound, http.StatusNotFound
+	}
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	err = temp.Decode(&result)
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	return result, nil, http.StatusOK
+}
+
+func (this *Mongo) ListReleaseCreations(userId string, query model.ReleaseCreationQueryOptions) (result []model.SmartServiceReleaseCreation, err error, code int) {
+	filter := bson.M{ReleaseCreationBson.UserId: userId}
+	if query.Status != "" {
+		filter[ReleaseCreationBson.Status] = query.Status
+	}
+	ctx, _ := getTimeoutContext()
+	cursor, err := this.releaseCreationCollection().Find(ctx, filter, createFindOptions(query))
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	defer cursor.Close(context.Background())
+	return readCursorResult[model.SmartServiceReleaseCreation](ctx, cursor)
+}
+
+func (this *Mongo) ListReleaseCreationsByStatus(status string) (result []model.SmartServiceReleaseCreation, err error, code int) {
+	ctx, _ := getTimeoutContext()
+	cursor, err := this.releaseCreationCollection().Find(ctx, bson.M{ReleaseCreationBson.Status: status})
+	if err != nil {
+		return result, err, http.StatusInternalServerError
+	}
+	defer cursor.Close(context.Background())
+	return readCursorResult[model.SmartServiceReleaseCreation](ctx, cursor)
+}
+
+func (this *Mongo) DeleteReleaseCreation(releaseId string) (error, int) {
+	ctx, _ := getTimeoutContext()
+	_, err := this.releaseCreationCollection().DeleteOne(ctx, bson.M{ReleaseCreationBson.ReleaseId: releaseId})
+	if err != nil {
+		return err, http.StatusInternalServerError
+	}
+	return nil, http.StatusOK
+}
@@ -37,6 +37,13 @@ const ReleaseBsonMarkedAsUnfinished = "marked_as_unfinished"
 const ReleaseBsonMarkedAsDeleted = "marked_as_deleted"
 const ReleaseBsonMarkedAtUnixTimestamp = "marked_at_unix_timestamp"
 
+const ReleaseBsonParameterDescriptions = "parsed_info.parameter_descriptions"
+const ReleaseBsonParameterIotTypeFilter = ReleaseBsonParameterDescriptions + ".iot_description.type_filter"
+const ReleaseBsonParameterCriteria = ReleaseBsonParameterDescriptions + ".iot_description.criteria"
+const ReleaseBsonParameterCharacteristicId = ReleaseBsonParameterDescriptions + ".characteristicid" //ParameterDescription.CharacteristicId has no bson tag
+const ReleaseBsonAnalyticsFlowId = "parsed_info.module_info.analytics.flow_id"
+const ReleaseBsonTags = "tags" //slice fields are not filled by getBsonFieldObject
+
 var ErrReleaseNotFound = errors.New("release not found")
 
 type SyncMarks struct {
@@ -69,6 +76,51 @@ func init() {
 			debug.PrintStack()
 			return err
 		}
+		err = db.ensureIndex(collection, "release_lifecycle_state_index", ReleaseBson.LifecycleState, true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "release_function_index", ReleaseBsonParameterCriteria+".function_id", true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "release_aspect_index", ReleaseBsonParameterCriteria+".aspect_id", true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "release_device_class_index", ReleaseBsonParameterCriteria+".device_class_id", true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "release_iot_type_index", ReleaseBsonParameterIotTypeFilter, true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "release_characteristic_index", ReleaseBsonParameterCharacteristicId, true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "release_analytics_index", ReleaseBsonAnalyticsFlowId, true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "release_category_index", ReleaseBson.Category, true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
+		err = db.ensureIndex(collection, "release_tags_index", ReleaseBsonTags, true, false)
+		if err != nil {
+			debug.PrintStack()
+			return err
+		}
 		return nil
 	})
 }
@@ -116,6 +168,28 @@ func (this *Mongo) GetMarkedReleases() (markedAsDeleted []model.SmartServiceRele
 
 }
 
+func (this *Mongo) GetAllReleases() (finished []model.SmartServiceReleaseExtended, marked []model.SmartServiceReleaseExtended, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	cursor, err := this.releaseCollection().Find(ctx, bson.M{})
+	if err != nil {
+		return finished, marked, err
+	}
+	defer cursor.Close(context.Background())
+	fullList, err, _ := readCursorResult[SmartServiceReleaseExtendedWithSyncMarks](ctx, cursor)
+	if err != nil {
+		return finished, marked, err
+	}
+	for _, element := range fullList {
+		if element.MarkedAsDeleted || element.MarkedAsUnfinished {
+			marked = append(marked, element.SmartServiceReleaseExtended)
+		} else {
+			finished = append(finished, element.SmartServiceReleaseExtended)
+		}
+	}
+	return finished, marked, nil
+}
+
 func (this *Mongo) SetRelease(element model.SmartServiceReleaseExtended, markAsUnfinished bool) (error, int) {
 	//store release
 	ctx, _ := getTimeoutContext()
@@ -215,6 +289,65 @@ func addAndFilter(filter bson.M, add bson.M) bson.M {
 func (this *Mongo) ListReleases(options model.ListReleasesOptions) (result []model.SmartServiceReleaseExtended, total int64, err error) {
 	ctx, _ := getTimeoutContext()
 	opt := createFindOptions(options)
+	filter := getReleaseListFilter(options)
+	cursor, err := this.releaseCollection().Find(ctx, filter, opt)
+	if err != nil {
+		return result, total, err
+	}
+	defer cursor.Close(context.Background())
+	result, err, _ = readCursorResult[model.SmartServiceReleaseExtended](ctx, cursor)
+	if err != nil {
+		return result, total, err
+	}
+	total, err = this.releaseCollection().CountDocuments(ctx, filter)
+	if err != nil {
+		return result, total, err
+	}
+	return result, total, err
+}
+
+// GetReleaseFacets counts the releases matching options per category and tag; limit, offset and sort are ignored
+func (this *Mongo) GetReleaseFacets(options model.ListReleasesOptions) (result model.ReleaseFacets, err error) {
+	ctx, cancel := getTimeoutContext()
+	defer cancel()
+	countSort := bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}
+	pipeline := bson.A{
+		bson.M{"$match": getReleaseListFilter(options)},
+		bson.M{"$facet": bson.M{
+			"categories": bson.A{
+				bson.M{"$match": bson.M{ReleaseBson.Category: bson.M{"$nin": bson.A{nil, ""}}}},
+				bson.M{"$group": bson.M{"_id": "$" + ReleaseBson.Category, "count": bson.M{"$sum": 1}}},
+				bson.M{"$sort": countSort},
+			},
+			"tags": bson.A{
+				bson.M{"$unwind": "$" + ReleaseBsonTags},
+				bson.M{"$group": bson.M{"_id": "$" + ReleaseBsonTags, "count": bson.M{"$sum": 1}}},
+				bson.M{"$sort": countSort},
+			},
+		}},
+	}
+	cursor, err := this.releaseCollection().Aggregate(ctx, pipeline)
+	if err != nil {
+		return result, err
+	}
+	defer cursor.Close(context.Background())
+	list, err, _ := readCursorResult[model.ReleaseFacets](ctx, cursor)
+	if err != nil {
+		return result, err
+	}
+	if len(list) > 0 {
+		result = list[0]
+	}
+	if result.Categories == nil {
+		result.Categories = []model.ReleaseFacetCount{}
+	}
+	if result.Tags == nil {
+		result.Tags = []model.ReleaseFacetCount{}
+	}
+	return result, nil
+}
+
+func getReleaseListFilter(options model.ListReleasesOptions) bson.M {
 	filter := bson.M{
 		ReleaseBsonMarkedAsDeleted:    bson.M{"$ne": true},
 		ReleaseBsonMarkedAsUnfinished: bson.M{"$ne": true},
@@ -232,6 +365,14 @@ func (this *Mongo) ListReleases(options model.ListReleasesOptions) (result []mod
 			},
 		})
 	}
+	filter = addAndFilter(filter, bson.M{
+		"$or": []interface{}{
+			bson.M{ReleaseBson.LifecycleState: bson.M{"$nin": []string{model.ReleaseLifecycleStateDraft, model.ReleaseLifecycleStatePendingApproval, model.ReleaseLifecycleStateRejected}}},
+			bson.M{ReleaseBson.Creator: options.DraftCreator},
+		},
+	})
+	filter = addReleaseCapabilityFilter(filter, options.ReleaseCapabilityFilter)
+	filter = addReleaseCatalogFilter(filter, options.ReleaseCatalogFilter)
 	if options.Latest {
 		filter = addAndFilter(filter, bson.M{
 			"$or": []interface{}{
@@ -240,20 +381,47 @@ func (this *Mongo) ListReleases(options model.ListReleasesOptions) (result []mod
 			},
 		})
 	}
-	cursor, err := this.releaseCollection().Find(ctx, filter, opt)
-	if err != nil {
-		return result, total, err
+	return filter
+}
+
+func addReleaseCatalogFilter(filter bson.M, catalog model.ReleaseCatalogFilter) bson.M {
+	if catalog.Category != "" {
+		filter = addAndFilter(filter, bson.M{ReleaseBson.Category: catalog.Category})
 	}
-	defer cursor.Close(context.Background())
-	result, err, _ = readCursorResult[model.SmartServiceReleaseExtended](ctx, cursor)
-	if err != nil {
-		return result, total, err
+	if len(catalog.Tags) > 0 {
+		filter = addAndFilter(filter, bson.M{ReleaseBsonTags: bson.M{"$all": catalog.Tags}})
 	}
-	total, err = this.releaseCollection().CountDocuments(ctx, filter)
-	if err != nil {
-		return result, total, err
+	return filter
+}
+
+func addReleaseCapabilityFilter(filter bson.M, capability model.ReleaseCapabilityFilter) bson.M {
+	criteria := bson.M{}
+	if capability.FunctionId != "" {
+		criteria["function_id"] = capability.FunctionId
 	}
-	return result, total, err
+	if capability.AspectId != "" {
+		criteria["aspect_id"] = capability.AspectId
+	}
+	if capability.DeviceClassId != "" {
+		criteria["device_class_id"] = capability.DeviceClassId
+	}
+	parameter := bson.M{}
+	if len(criteria) > 0 {
+		parameter["iot_description.criteria"] = bson.M{"$elemMatch": criteria}
+	}
+	if capability.IotType != "" {
+		parameter["iot_description.type_filter"] = capability.IotType
+	}
+	if len(parameter) > 0 {
+		filter = addAndFilter(filter, bson.M{ReleaseBsonParameterDescriptions: bson.M{"$elemMatch": parameter}})
+	}
+	if capability.CharacteristicId != "" {
+		filter = addAndFilter(filter, bson.M{ReleaseBsonParameterCharacteristicId: capability.CharacteristicId})
+	}
+	if capability.HasAnalytics {
+		filter = addAndFilter(filter, bson.M{ReleaseBsonAnalyticsFlowId: bson.M{"$exists": true}})
+	}
+	return filter
 }
 
 func (this *Mongo) GetReleasesByDesignId(designId string) (result []model.SmartServiceReleaseExtended, err error) {
@@ -267,6 +435,21 @@ func (this *Mongo) GetReleasesByDesignId(designId string) (result []model.SmartS
 	return result, err
 }
 
+func (this *Mongo) GetReleasesByLifecycleState(lifecycleState string) (result []model.SmartServiceReleaseExtended, err error) {
+	ctx, _ := getTimeoutContext()
+	cursor, err := this.releaseCollection().Find(ctx, bson.M{
+		ReleaseBson.LifecycleState:    lifecycleState,
+		ReleaseBsonMarkedAsDeleted:    bson.M{"$ne": true},
+		ReleaseBsonMarkedAsUnfinished: bson.M{"$ne": true},
+	}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return result, err
+	}
+	defer cursor.Close(context.Background())
+	result, err, _ = readCursorResult[model.SmartServiceReleaseExtended](ctx, cursor)
+	return result, err
+}
+
 func (this *Mongo) GetPreviousReleases(releaseId string) (result []model.SmartServiceReleaseExtended, err error) {
 	ctx, _ := getTimeoutContext()
 	cursor, err := this.releaseCollection().Find(ctx, bson.M{ReleaseBson.NewReleaseId: releaseId, ReleaseBsonMarkedAsDeleted: bson.M{"$ne": true}})
@@ -0,0 +1,45 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+const SmartServiceBundleVersion = 1
+
+const (
+	SmartServiceBundleKindDesign  = "design"
+	SmartServiceBundleKindRelease = "release"
+)
+
+// SmartServiceBundle is a self-contained export of a design or release that may be imported on another platform.
+// ids are not kept on import; SourceId only references the exported element.
+type SmartServiceBundle struct {
+	Version           int                      `json:"version"`
+	Kind              string                   `json:"kind"`
+	SourceId          string                   `json:"source_id"`
+	Name              string                   `json:"name"`
+	Description       string                   `json:"description"`
+	BpmnXml           string                   `json:"bpmn_xml"`
+	SvgXml            string                   `json:"svg_xml"`
+	ParsedInfo        *SmartServiceReleaseInfo `json:"parsed_info,omitempty"` //may be missing for designs that are not (yet) releasable
+	CharacteristicIds []string                 `json:"characteristic_ids"`
+	ExportedAt        int64                    `json:"exported_at"` //unix timestamp
+}
+
+// SmartServiceBundleImport is the request body of the bundle imports; an exported SmartServiceBundle is a valid import request.
+type SmartServiceBundleImport struct {
+	SmartServiceBundle
+	CharacteristicIdMapping map[string]string `json:"characteristic_id_mapping,omitempty"` //maps characteristic ids of the exporting platform to ids of this platform; unmapped ids are kept
+}
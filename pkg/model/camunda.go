@@ -21,3 +21,13 @@ type HistoricProcessInstance struct {
 	EndTime     string `json:"endTime"`
 	BusinessKey string `json:"businessKey"`
 }
+
+// CamundaStartForm is submitted to camunda to start the process of an instance
+type CamundaStartForm struct {
+	BusinessKey string                          `json:"businessKey"`
+	Variables   map[string]CamundaStartVariable `json:"variables"`
+}
+
+type CamundaStartVariable struct {
+	Value interface{} `json:"value"`
+}
@@ -0,0 +1,33 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+const DesignDiagnosticSeverityError = "error"
+const DesignDiagnosticSeverityWarning = "warning"
+
+type DesignLintResult struct {
+	Valid       bool               `json:"valid"` //true if no diagnostic has the severity "error"
+	Diagnostics []DesignDiagnostic `json:"diagnostics"`
+}
+
+type DesignDiagnostic struct {
+	Severity    string `json:"severity"` //"error" or "warning"
+	Code        string `json:"code"`     //machine readable identifier of the problem (e.g. "duplicate_param_id")
+	ElementId   string `json:"element_id,omitempty"`
+	FormFieldId string `json:"form_field_id,omitempty"`
+	Message     string `json:"message"`
+}
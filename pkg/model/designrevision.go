@@ -0,0 +1,44 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+type SmartServiceDesignRevision struct {
+	SmartServiceDesign `bson:",inline"`
+	Author             string `json:"author" bson:"author"` //user who saved this revision
+}
+
+type SmartServiceDesignDiff struct {
+	DesignId     string            `json:"design_id"`
+	FromRevision int64             `json:"from_revision"`
+	ToRevision   int64             `json:"to_revision"`
+	Elements     DesignDiffSection `json:"elements"`    //bpmn elements identified by their id
+	FormFields   DesignDiffSection `json:"form_fields"` //start event form fields identified by start event id and field id
+}
+
+type DesignDiffSection struct {
+	Added   []DesignDiffEntry `json:"added"`
+	Removed []DesignDiffEntry `json:"removed"`
+	Changed []DesignDiffEntry `json:"changed"`
+}
+
+type DesignDiffEntry struct {
+	Id       string   `json:"id"`
+	ParentId string   `json:"parent_id,omitempty"` //id of the start event of a form field
+	Type     string   `json:"type"`                //xml tag of bpmn elements; field type of form fields
+	Name     string   `json:"name,omitempty"`
+	Changes  []string `json:"changes,omitempty"` //changed attributes (e.g. "name"), form field properties (e.g. "property:iot") or "content"
+}
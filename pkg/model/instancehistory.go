@@ -0,0 +1,56 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+const (
+	InstanceEventCreated            = "created"
+	InstanceEventRedeployed         = "redeployed"
+	InstanceEventInfoUpdated        = "info_updated"
+	InstanceEventError              = "error"
+	InstanceEventModulesAdded       = "modules_added"
+	InstanceEventModuleDeleted      = "module_deleted"
+	InstanceEventVariablesSet       = "variables_set"
+	InstanceEventVariableDeleted    = "variable_deleted"
+	InstanceEventMaintenanceStarted = "maintenance_started"
+	InstanceEventDeleted            = "deleted"
+)
+
+// SmartServiceInstanceEvent is an entry of the append-only history of an instance; fields not relevant to the Type are empty
+type SmartServiceInstanceEvent struct {
+	Id                  string             `json:"id" bson:"id"`
+	InstanceId          string             `json:"instance_id" bson:"instance_id"`
+	Type                string             `json:"type" bson:"type"` //one of InstanceEvent*
+	Time                int64              `json:"time" bson:"time"` //unix timestamp in milliseconds
+	Actor               InstanceEventActor `json:"actor" bson:"actor"`
+	ReleaseId           string             `json:"release_id,omitempty" bson:"release_id,omitempty"`                       //created
+	OldReleaseId        string             `json:"old_release_id,omitempty" bson:"old_release_id,omitempty"`               //redeployed
+	NewReleaseId        string             `json:"new_release_id,omitempty" bson:"new_release_id,omitempty"`               //redeployed
+	ChangedParameterIds []string           `json:"changed_parameter_ids,omitempty" bson:"changed_parameter_ids,omitempty"` //redeployed
+	Name                string             `json:"name,omitempty" bson:"name,omitempty"`                                   //info_updated
+	Description         string             `json:"description,omitempty" bson:"description,omitempty"`                     //info_updated
+	Error               string             `json:"error,omitempty" bson:"error,omitempty"`                                 //error
+	ModuleIds           []string           `json:"module_ids,omitempty" bson:"module_ids,omitempty"`                       //modules_added, module_deleted
+	VariableNames       []string           `json:"variable_names,omitempty" bson:"variable_names,omitempty"`               //variables_set, variable_deleted
+	MaintenanceId       string             `json:"maintenance_id,omitempty" bson:"maintenance_id,omitempty"`               //maintenance_started
+	PublicEventId       string             `json:"public_event_id,omitempty" bson:"public_event_id,omitempty"`             //maintenance_started
+}
+
+// InstanceEventActor is either the user who used the api or the camunda process instance which called the repository
+type InstanceEventActor struct {
+	UserId            string `json:"user_id,omitempty" bson:"user_id,omitempty"`
+	ProcessInstanceId string `json:"process_instance_id,omitempty" bson:"process_instance_id,omitempty"`
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+const (
+	InstanceMigrationJobStatusRunning  = "running"
+	InstanceMigrationJobStatusFinished = "finished"
+	InstanceMigrationJobStatusFailed   = "failed" //the job has been interrupted, e.g. by a restart of the service
+)
+
+const (
+	InstanceMigrationStatusPending    = "pending"
+	InstanceMigrationStatusMigrated   = "migrated"
+	InstanceMigrationStatusNeedsInput = "needs_input" //the instance has to be migrated manually with RedeployInstance
+	InstanceMigrationStatusFailed     = "failed"
+)
+
+// InstanceMigrationJob tracks the redeployment of all instances with new_release_id == release_id
+type InstanceMigrationJob struct {
+	Id         string                    `json:"id" bson:"id"`
+	UserId     string                    `json:"user_id" bson:"user_id"` //user who started the job
+	ReleaseId  string                    `json:"release_id" bson:"release_id"`
+	Status     string                    `json:"status" bson:"status"`
+	CreatedAt  int64                     `json:"created_at" bson:"created_at"`
+	FinishedAt int64                     `json:"finished_at,omitempty" bson:"finished_at"`
+	Error      string                    `json:"error,omitempty" bson:"error"`
+	Results    []InstanceMigrationResult `json:"results" bson:"results"`
+}
+
+type InstanceMigrationResult struct {
+	InstanceId          string   `json:"instance_id" bson:"instance_id"`
+	InstanceName        string   `json:"instance_name" bson:"instance_name"`
+	PreviousReleaseId   string   `json:"previous_release_id" bson:"previous_release_id"`
+	Status              string   `json:"status" bson:"status"`
+	MissingParameterIds []string `json:"missing_parameter_ids,omitempty" bson:"missing_parameter_ids"` //set if status == needs_input
+	Error               string   `json:"error,omitempty" bson:"error"`
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+const ErrMissingCamundaProcessInstance = "missing camunda process instance"
+
+const (
+	InstanceStateCreating    = "creating"    //stored, camunda process not yet started
+	InstanceStateStarting    = "starting"    //camunda process is running
+	InstanceStateReady       = "ready"       //camunda process has finished
+	InstanceStateMaintenance = "maintenance" //maintenance procedures are running
+	InstanceStateError       = "error"
+	InstanceStateDeleting    = "deleting"
+	InstanceStateStopped     = "stopped" //camunda process is missing before it finished
+)
+
+var InstanceStates = []string{
+	InstanceStateCreating,
+	InstanceStateStarting,
+	InstanceStateReady,
+	InstanceStateMaintenance,
+	InstanceStateError,
+	InstanceStateDeleting,
+	InstanceStateStopped,
+}
+
+// InstanceStateTransitions lists the allowed target states per state; new instances start with InstanceStateCreating
+var InstanceStateTransitions = map[string][]string{
+	InstanceStateCreating:    {InstanceStateStarting, InstanceStateReady, InstanceStateError, InstanceStateStopped, InstanceStateDeleting}, //ready if the process finishes before starting is stored
+	InstanceStateStarting:    {InstanceStateReady, InstanceStateError, InstanceStateStopped, InstanceStateDeleting},
+	InstanceStateReady:       {InstanceStateMaintenance, InstanceStateError, InstanceStateStopped, InstanceStateDeleting},
+	InstanceStateMaintenance: {InstanceStateReady, InstanceStateError, InstanceStateStopped, InstanceStateDeleting},
+	InstanceStateError:       {InstanceStateStarting, InstanceStateReady, InstanceStateStopped, InstanceStateDeleting},
+	InstanceStateStopped:     {InstanceStateStarting, InstanceStateReady, InstanceStateError, InstanceStateDeleting},
+	InstanceStateDeleting:    {InstanceStateError},
+}
+
+// GetState returns the state with a fallback for instances stored before the state field existed,
+// derived from Deleting, Error, RunningMaintenanceIds and Ready
+func (this SmartServiceInstance) GetState() string {
+	if this.State != "" {
+		return this.State
+	}
+	switch {
+	case this.Deleting:
+		return InstanceStateDeleting
+	case this.Error == ErrMissingCamundaProcessInstance:
+		return InstanceStateStopped
+	case this.Error != "":
+		return InstanceStateError
+	case len(this.RunningMaintenanceIds) > 0:
+		return InstanceStateMaintenance
+	case this.Ready:
+		return InstanceStateReady
+	default:
+		return InstanceStateStarting
+	}
+}
+
+// SetState validates the transition from the current state and updates State and StateChangedAt;
+// setting the current state again is a no-op. instances without state may only be set to InstanceStateCreating
+// (stored instances without state are migrated on startup with GetState).
+func (this *SmartServiceInstance) SetState(state string) error {
+	if !slices.Contains(InstanceStates, state) {
+		return fmt.Errorf("unknown instance state %v", state)
+	}
+	current := this.State
+	if current == state {
+		return nil
+	}
+	if current == "" {
+		if state != InstanceStateCreating {
+			return fmt.Errorf("new instances must have the state %v", InstanceStateCreating)
+		}
+	} else if !slices.Contains(InstanceStateTransitions[current], state) {
+		return fmt.Errorf("instance state transition from %v to %v is not allowed", current, state)
+	}
+	this.State = state
+	this.StateChangedAt = time.Now().Unix()
+	return nil
+}
@@ -17,24 +17,33 @@
 package model
 
 type SmartServiceDesign struct {
-	Id          string `json:"id" bson:"id"`
-	UserId      string `json:"user_id" bson:"user_id"`
-	Name        string `json:"name" bson:"name"`
-	Description string `json:"description"`
-	BpmnXml     string `json:"bpmn_xml" bson:"bpmn_xml"`
-	SvgXml      string `json:"svg_xml" bson:"svg_xml"`
-	UpdatedAt   int64  `json:"updated_at" bson:"updated_at"` //unix timestamp, set by service on creation
+	Id              string          `json:"id" bson:"id"`
+	UserId          string          `json:"user_id" bson:"user_id"`
+	Name            string          `json:"name" bson:"name"`
+	Description     string          `json:"description"`
+	BpmnXml         string          `json:"bpmn_xml" bson:"bpmn_xml"`
+	SvgXml          string          `json:"svg_xml" bson:"svg_xml"`
+	UpdatedAt       int64           `json:"updated_at" bson:"updated_at"` //unix timestamp, set by service on creation
+	Revision        int64           `json:"revision" bson:"revision"`     //set by service on every update
+	Template        bool            `json:"template" bson:"template"`     //set by admins with PUT /designs/{id}/template; templates are readable by everyone
+	PermissionsInfo PermissionsInfo `json:"permissions_info,omitempty" bson:"-"`
 }
 
 // cqrs
 type SmartServiceRelease struct {
-	Id           string `json:"id" bson:"id"`
-	DesignId     string `json:"design_id" bson:"design_id"`
-	Name         string `json:"name" bson:"name"`
-	Description  string `json:"description" bson:"description"`
-	CreatedAt    int64  `json:"created_at" bson:"created_at"` //unix timestamp, set by service on creation
-	NewReleaseId string `json:"new_release_id,omitempty"`
-	Creator      string `json:"creator"`
+	Id             string                       `json:"id" bson:"id"`
+	DesignId       string                       `json:"design_id" bson:"design_id"`
+	Name           string                       `json:"name" bson:"name"`
+	Description    string                       `json:"description" bson:"description"`
+	CreatedAt      int64                        `json:"created_at" bson:"created_at"` //unix timestamp, set by service on creation
+	NewReleaseId   string                       `json:"new_release_id,omitempty"`
+	Creator        string                       `json:"creator"`
+	LifecycleState string                       `json:"lifecycle_state" bson:"lifecycle_state"`       //one of ReleaseLifecycleState*; empty for releases created before lifecycle states existed (interpreted as published)
+	Stats          *ReleaseInstanceStats        `json:"stats,omitempty" bson:"-"`                     //optional, set if query parameter with_stats=true
+	Approval       *SmartServiceReleaseApproval `json:"approval,omitempty" bson:"approval,omitempty"` //set when a release in ReleaseLifecycleStatePendingApproval is approved or rejected
+	Category       string                       `json:"category,omitempty" bson:"category,omitempty"` //defaults to the senergy:category attribute of the bpmn process/collaboration
+	Tags           []string                     `json:"tags,omitempty" bson:"tags,omitempty"`         //defaults to the comma-separated senergy:tags attribute of the bpmn process/collaboration
+	Icon           string                       `json:"icon,omitempty" bson:"icon,omitempty"`         //icon/image reference (url or data-uri); defaults to the senergy:icon attribute of the bpmn process/collaboration
 }
 
 type SmartServiceReleaseWithUsableFlag struct {
@@ -50,6 +59,16 @@ type SmartServiceReleaseExtended struct {
 	PermissionsInfo     PermissionsInfo         `json:"permissions_info,omitempty" bson:"-"` //optional, set if query parameter permissions_info=true
 }
 
+type SmartServiceReleasePreviewRequest struct {
+	DesignId string `json:"design_id"` //used if bpmn_xml is empty
+	BpmnXml  string `json:"bpmn_xml"`
+}
+
+type SmartServiceReleasePreview struct {
+	ParsedInfo SmartServiceReleaseInfo         `json:"parsed_info"`
+	Parameters []SmartServiceExtendedParameter `json:"parameters,omitempty"` //optional, set if query parameter with_parameters=true
+}
+
 type SmartServiceReleaseExtendedWithUsableFlag struct {
 	SmartServiceReleaseExtended
 	Usable bool `json:"usable"`
@@ -77,9 +96,13 @@ type SmartServiceInstance struct {
 	RunningMaintenanceIds    []string        `json:"running_maintenance_ids,omitempty"`
 	Ready                    bool            `json:"ready" bson:"ready"`
 	Deleting                 bool            `json:"deleting,omitempty" bson:"deleting"`
-	Error                    string          `json:"error,omitempty" bson:"error"` //is set if module-worker notifies the repository about an error, may be set by module.error
-	CreatedAt                int64           `json:"created_at" bson:"created_at"` //unix timestamp, set by service on creation
-	UpdatedAt                int64           `json:"updated_at" bson:"updated_at"` //unix timestamp, set by service on creation
+	Error                    string          `json:"error,omitempty" bson:"error"`                     //is set if module-worker notifies the repository about an error, may be set by module.error
+	CreatedAt                int64           `json:"created_at" bson:"created_at"`                     //unix timestamp, set by service on creation
+	UpdatedAt                int64           `json:"updated_at" bson:"updated_at"`                     //unix timestamp, set by service on creation
+	State                    string          `json:"state" bson:"state"`                               //one of InstanceState*
+	StateChangedAt           int64           `json:"state_changed_at" bson:"state_changed_at"`         //unix timestamp of the last state change
+	Test                     bool            `json:"test,omitempty" bson:"test,omitempty"`             //test instances are hidden in the default instance list and removed by the cleanup after ExpiresAt
+	ExpiresAt                int64           `json:"expires_at,omitempty" bson:"expires_at,omitempty"` //unix timestamp, only set for test instances
 }
 
 type SmartServiceInstanceInit struct {
@@ -0,0 +1,36 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+const JsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// ParameterJsonSchema describes the parameters of a release or maintenance procedure as JSON Schema.
+// the described object maps parameter ids to parameter values.
+type ParameterJsonSchema struct {
+	Schema               string                         `json:"$schema,omitempty"`
+	Title                string                         `json:"title,omitempty"`
+	Description          string                         `json:"description,omitempty"`
+	Type                 string                         `json:"type,omitempty"` //empty for unknown types -> any value
+	Properties           map[string]ParameterJsonSchema `json:"properties,omitempty"`
+	Required             []string                       `json:"required,omitempty"`
+	AdditionalProperties *bool                          `json:"additionalProperties,omitempty"`
+	Items                *ParameterJsonSchema           `json:"items,omitempty"`
+	Enum                 []interface{}                  `json:"enum,omitempty"`
+	EnumLabels           []string                       `json:"x-enum-labels,omitempty"` //labels of the enum values, same order as Enum
+	Default              interface{}                    `json:"default,omitempty"`
+	Order                *int                           `json:"x-order,omitempty"`
+}
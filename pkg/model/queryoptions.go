@@ -65,10 +65,13 @@ func (this VariableQueryOptions) GetSort() string {
 }
 
 type DesignQueryOptions struct {
-	Limit  int
-	Offset int
-	Sort   string
-	Search string
+	Limit    int
+	Offset   int
+	Sort     string
+	Search   string
+	Ids      []string //use option only if Ids != nil
+	Template *bool    //use option only if Template != nil
+	UserId   string   //requesting user; set by the controller; with Template == false, templates of UserId are still listed
 }
 
 func (this DesignQueryOptions) GetLimit() int64 {
@@ -94,6 +97,8 @@ type ReleaseQueryOptions struct {
 	Latest bool
 	Rights string
 	Ids    []string
+	ReleaseCapabilityFilter
+	ReleaseCatalogFilter
 }
 
 func (this ReleaseQueryOptions) GetLimit() int64 {
@@ -123,12 +128,15 @@ func (this ReleaseQueryOptions) GetSortAsc() bool {
 }
 
 type ListReleasesOptions struct {
-	InIds  []string //use option only if InIds != nil
-	Latest bool     //new_release_id==""
-	Limit  int
-	Offset int
-	Sort   string
-	Search string
+	InIds        []string //use option only if InIds != nil
+	Latest       bool     //new_release_id==""
+	Limit        int
+	Offset       int
+	Sort         string
+	Search       string
+	DraftCreator string //drafts and unapproved releases are only listed if they have been created by this user
+	ReleaseCapabilityFilter
+	ReleaseCatalogFilter
 }
 
 func (this ListReleasesOptions) GetLimit() int64 {
@@ -146,12 +154,39 @@ func (this ListReleasesOptions) GetSort() string {
 	return this.Sort
 }
 
+// ReleaseCapabilityFilter filters releases by their parsed info.
+// FunctionId, AspectId, DeviceClassId and IotType must all match the same start parameter;
+// empty fields are ignored.
+type ReleaseCapabilityFilter struct {
+	FunctionId       string
+	AspectId         string
+	DeviceClassId    string
+	IotType          FilterPossibility //device, device_service_group, group or import
+	CharacteristicId string
+	HasAnalytics     bool //only releases with analytics flows
+}
+
 type InstanceQueryOptions struct {
-	Limit     int
-	Offset    int
-	Sort      string
-	ReleaseId string
-	IDs       []string
+	Limit              int
+	Offset             int
+	Sort               string
+	ReleaseId          string
+	IDs                []string
+	Test               *bool    //use option only if Test != nil
+	States             []string //use option only if len(States) > 0
+	StateChangedBefore int64    //unix timestamp; use option only if StateChangedBefore > 0
+	Search             string   //mongo text search on name and description; use option only if Search != ""
+	DesignId           string   //use option only if DesignId != ""
+	Ready              *bool    //use option only if Ready != nil
+	HasError           *bool    //stored instance error, module errors are not considered; use option only if HasError != nil
+	Deleting           *bool    //use option only if Deleting != nil
+	OutdatedRelease    *bool    //true: only instances with a NewReleaseId; use option only if OutdatedRelease != nil
+	CreatedAfter       int64    //unix timestamp; use option only if CreatedAfter > 0
+	CreatedBefore      int64    //unix timestamp; use option only if CreatedBefore > 0
+	UpdatedAfter       int64    //unix timestamp; use option only if UpdatedAfter > 0
+	UpdatedBefore      int64    //unix timestamp; use option only if UpdatedBefore > 0
+	Shared             *bool    //true: only instances of other users, false: only instances of UserId; use option only if Shared != nil
+	UserId             string   //requesting user; set by the controller and used in combination with Shared
 }
 
 func (this InstanceQueryOptions) GetLimit() int64 {
@@ -168,3 +203,67 @@ func (this InstanceQueryOptions) GetSort() string {
 	}
 	return this.Sort
 }
+
+type DesignRevisionQueryOptions struct {
+	Limit  int
+	Offset int
+	Sort   string
+}
+
+func (this DesignRevisionQueryOptions) GetLimit() int64 {
+	return int64(this.Limit)
+}
+
+func (this DesignRevisionQueryOptions) GetOffset() int64 {
+	return int64(this.Offset)
+}
+
+func (this DesignRevisionQueryOptions) GetSort() string {
+	if this.Sort == "" {
+		return "revision.desc"
+	}
+	return this.Sort
+}
+
+type ReleaseCreationQueryOptions struct {
+	Limit  int
+	Offset int
+	Sort   string
+	Status string //use option only if Status != ""
+}
+
+func (this ReleaseCreationQueryOptions) GetLimit() int64 {
+	return int64(this.Limit)
+}
+
+func (this ReleaseCreationQueryOptions) GetOffset() int64 {
+	return int64(this.Offset)
+}
+
+func (this ReleaseCreationQueryOptions) GetSort() string {
+	if this.Sort == "" {
+		return "created_at.desc"
+	}
+	return this.Sort
+}
+
+type InstanceEventQueryOptions struct {
+	Limit  int
+	Offset int
+	Sort   string
+}
+
+func (this InstanceEventQueryOptions) GetLimit() int64 {
+	return int64(this.Limit)
+}
+
+func (this InstanceEventQueryOptions) GetOffset() int64 {
+	return int64(this.Offset)
+}
+
+func (this InstanceEventQueryOptions) GetSort() string {
+	if this.Sort == "" {
+		return "time.asc"
+	}
+	return this.Sort
+}
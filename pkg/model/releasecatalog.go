@@ -0,0 +1,34 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+// ReleaseCatalogFilter filters releases by their catalog information; empty fields are ignored.
+type ReleaseCatalogFilter struct {
+	Category string
+	Tags     []string //releases must have all listed tags
+}
+
+// ReleaseFacets counts the releases matching a ReleaseQueryOptions per category and tag
+type ReleaseFacets struct {
+	Categories []ReleaseFacetCount `json:"categories"`
+	Tags       []ReleaseFacetCount `json:"tags"`
+}
+
+type ReleaseFacetCount struct {
+	Value string `json:"value" bson:"_id"`
+	Count int64  `json:"count" bson:"count"`
+}
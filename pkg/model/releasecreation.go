@@ -0,0 +1,36 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+const (
+	ReleaseCreationStatusPending   = "pending"   //design is parsed and validated
+	ReleaseCreationStatusDeploying = "deploying" //release is stored but not finished (permissions, camunda deployment, replacement of old releases)
+	ReleaseCreationStatusReady     = "ready"
+	ReleaseCreationStatusFailed    = "failed"
+)
+
+// SmartServiceReleaseCreation describes the progress of an asynchronous release creation
+type SmartServiceReleaseCreation struct {
+	ReleaseId string `json:"release_id" bson:"release_id"`
+	DesignId  string `json:"design_id" bson:"design_id"`
+	Name      string `json:"name" bson:"name"`
+	UserId    string `json:"user_id" bson:"user_id"`
+	Status    string `json:"status" bson:"status"`
+	Error     string `json:"error,omitempty" bson:"error"` //set if status == failed
+	CreatedAt int64  `json:"created_at" bson:"created_at"`
+	UpdatedAt int64  `json:"updated_at" bson:"updated_at"`
+}
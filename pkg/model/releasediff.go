@@ -0,0 +1,66 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+type SmartServiceReleaseDiff struct {
+	ReleaseId             string                          `json:"release_id"`
+	OtherReleaseId        string                          `json:"other_release_id"`
+	Parameters            ParameterDiffSection            `json:"parameters"` //init parameters identified by their id
+	MaintenanceProcedures MaintenanceProcedureDiffSection `json:"maintenance_procedures"`
+	Analytics             AnalyticsDiffSection            `json:"analytics"`
+	ReenterParameterIds   []string                        `json:"reenter_parameter_ids"` //init parameters which have to be (re-)entered when an instance is redeployed with other_release_id
+}
+
+type ParameterDiffSection struct {
+	Added   []ParameterDiffEntry `json:"added"`
+	Removed []ParameterDiffEntry `json:"removed"`
+	Changed []ParameterDiffEntry `json:"changed"`
+}
+
+type ParameterDiffEntry struct {
+	Id      string   `json:"id"`
+	Label   string   `json:"label,omitempty"`
+	Type    string   `json:"type"`
+	Changes []string `json:"changes,omitempty"` //changed fields (e.g. "type", "options", "iot_criteria", "characteristic")
+}
+
+// MaintenanceProcedureDiffSection lists maintenance procedures identified by their public_event_id
+type MaintenanceProcedureDiffSection struct {
+	Added   []MaintenanceProcedureDiffEntry `json:"added"`
+	Removed []MaintenanceProcedureDiffEntry `json:"removed"`
+	Changed []MaintenanceProcedureDiffEntry `json:"changed"`
+}
+
+type MaintenanceProcedureDiffEntry struct {
+	PublicEventId string                `json:"public_event_id"`
+	BpmnId        string                `json:"bpmn_id"`
+	Changes       []string              `json:"changes,omitempty"`    //"bpmn_id" or "parameters"
+	Parameters    *ParameterDiffSection `json:"parameters,omitempty"` //set if the parameters of the procedure changed
+}
+
+// AnalyticsDiffSection lists analytics modules identified by their flow_id
+type AnalyticsDiffSection struct {
+	Added   []AnalyticsDiffEntry `json:"added"`
+	Removed []AnalyticsDiffEntry `json:"removed"`
+	Changed []AnalyticsDiffEntry `json:"changed"`
+}
+
+type AnalyticsDiffEntry struct {
+	FlowId  string   `json:"flow_id"`
+	Name    string   `json:"name"`
+	Changes []string `json:"changes,omitempty"` //"name" or "desc"
+}
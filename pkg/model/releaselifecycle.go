@@ -0,0 +1,62 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+const (
+	ReleaseLifecycleStateDraft      = "draft"      //only visible to the creator; does not replace older releases
+	ReleaseLifecycleStatePublished  = "published"  //default
+	ReleaseLifecycleStateDeprecated = "deprecated" //usable but flagged
+	ReleaseLifecycleStateRetired    = "retired"    //may not be used to create or redeploy instances
+
+	ReleaseLifecycleStatePendingApproval = "pending_approval" //only visible to the creator and reviewers; not deployed until approved
+	ReleaseLifecycleStateRejected        = "rejected"         //only visible to the creator; may be deleted
+)
+
+type SmartServiceReleaseApproval struct {
+	Approved   bool   `json:"approved" bson:"approved"`
+	ReviewerId string `json:"reviewer_id" bson:"reviewer_id"`
+	Comment    string `json:"comment" bson:"comment"`
+	ReviewedAt int64  `json:"reviewed_at" bson:"reviewed_at"` //unix timestamp
+}
+
+type SmartServiceReleaseReview struct {
+	Comment string `json:"comment"` //required to reject a release
+}
+
+type SmartServiceReleaseLifecycleStateUpdate struct {
+	LifecycleState string `json:"lifecycle_state"`
+	Message        string `json:"message,omitempty"` //optional, added to the notification of instance owners
+}
+
+// GetLifecycleState returns the lifecycle state with ReleaseLifecycleStatePublished as fallback for old releases
+func (this SmartServiceRelease) GetLifecycleState() string {
+	if this.LifecycleState == "" {
+		return ReleaseLifecycleStatePublished
+	}
+	return this.LifecycleState
+}
+
+// IsReleased returns false for drafts and releases that have not been approved.
+// unreleased releases are only visible to the creator and do not replace older releases.
+func (this SmartServiceRelease) IsReleased() bool {
+	switch this.GetLifecycleState() {
+	case ReleaseLifecycleStateDraft, ReleaseLifecycleStatePendingApproval, ReleaseLifecycleStateRejected:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,30 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+import (
+	permmodel "github.com/SENERGY-Platform/permissions-v2/pkg/model"
+)
+
+type ReleasePermissions = permmodel.ResourcePermissions
+
+type ReleasePermissionsUpdateResult struct {
+	Permissions       ReleasePermissions `json:"permissions"`
+	UpdatedReleaseIds []string           `json:"updated_release_ids"`
+	SkippedReleaseIds []string           `json:"skipped_release_ids"` //other versions of the design without administrate rights of the user
+	FailedReleaseIds  []string           `json:"failed_release_ids"`  //other versions of the design where the permissions could not be set
+}
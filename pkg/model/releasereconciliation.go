@@ -0,0 +1,29 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+// ReleaseReconciliationReport describes a comparison of the releases in the database with the process definitions deployed in camunda
+type ReleaseReconciliationReport struct {
+	StartedAt           int64    `json:"started_at"`
+	FinishedAt          int64    `json:"finished_at"`
+	DryRun              bool     `json:"dry_run"`              //if true, the changes are only reported
+	CheckedReleases     int      `json:"checked_releases"`     //count of finished releases
+	Redeployed          []string `json:"redeployed"`           //ids of releases missing in camunda
+	OrphanedDeployments []string `json:"orphaned_deployments"` //process definition keys without release
+	RemovedDeployments  []string `json:"removed_deployments"`  //orphaned deployments that have been removed; only if release_reconciliation_remove_orphans is enabled
+	Errors              []string `json:"errors,omitempty"`
+}
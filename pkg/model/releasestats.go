@@ -0,0 +1,33 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package model
+
+// ReleaseInstanceStats counts the instances of a release; only instances readable by the requesting user are counted
+type ReleaseInstanceStats struct {
+	Instances int64 `json:"instances"`
+	Ready     int64 `json:"ready"`
+	Error     int64 `json:"error"`
+	Outdated  int64 `json:"outdated"` //instances with a new_release_id (a newer release of the design is available)
+}
+
+type SmartServiceReleaseStats struct {
+	ReleaseId string `json:"release_id"`
+	Name      string `json:"name"`
+	CreatedAt int64  `json:"created_at"`
+	ReleaseInstanceStats
+	PreviousReleases []SmartServiceReleaseStats `json:"previous_releases,omitempty"` //readable older releases of the same design, newest first
+}
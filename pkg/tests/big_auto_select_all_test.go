@@ -130,13 +130,9 @@ func TestIBigInstanceAutoSelectAllInput(t *testing.T) {
 			},
 			Parameters: []model.SmartServiceParameter{
 				{
-					Id: "location",
-					Value: map[string]interface{}{
-						"Latitude":  51.338527718877394,
-						"Longitude": 12.38074998525586,
-					},
-					Label:      "location",
-					ValueLabel: "Augustusplatz, Leipzig",
+					Id:    "stats",
+					Value: []interface{}{"hourly"},
+					Label: "Stats",
 				},
 			},
 		})
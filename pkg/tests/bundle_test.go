@@ -0,0 +1,252 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/controller"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestBundles(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, err := apiTestEnv(ctx, wg, false, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	design := model.SmartServiceDesign{}
+	t.Run("create design", func(t *testing.T) {
+		resp, err := post(userToken, apiUrl+"/designs", model.SmartServiceDesign{
+			BpmnXml: resources.ParamsBpmn,
+			SvgXml:  resources.ParamsSvg,
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if resp.StatusCode != http.StatusOK {
+			temp, _ := io.ReadAll(resp.Body)
+			t.Error(resp.StatusCode, string(temp))
+			return
+		}
+		err = json.NewDecoder(resp.Body).Decode(&design)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	})
+
+	release := model.SmartServiceRelease{}
+	t.Run("create release", func(t *testing.T) {
+		resp, err := post(userToken, apiUrl+"/releases", model.SmartServiceRelease{
+			DesignId:    design.Id,
+			Name:        "release name",
+			Description: "release description",
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if resp.StatusCode != http.StatusOK {
+			temp, _ := io.ReadAll(resp.Body)
+			t.Error(resp.StatusCode, string(temp))
+			return
+		}
+		err = json.NewDecoder(resp.Body).Decode(&release)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	})
+
+	designBundle := model.SmartServiceBundle{}
+	t.Run("export design", func(t *testing.T) {
+		designBundle = testExportBundle(t, apiUrl+"/designs/"+url.PathEscape(design.Id)+"/export")
+		if designBundle.Kind != model.SmartServiceBundleKindDesign || designBundle.SourceId != design.Id || designBundle.BpmnXml != resources.ParamsBpmn || designBundle.ParsedInfo == nil {
+			t.Errorf("%#v", designBundle)
+		}
+	})
+
+	releaseBundle := model.SmartServiceBundle{}
+	t.Run("export release", func(t *testing.T) {
+		releaseBundle = testExportBundle(t, apiUrl+"/releases/"+url.PathEscape(release.Id)+"/export")
+		if releaseBundle.Kind != model.SmartServiceBundleKindRelease || releaseBundle.SourceId != release.Id || releaseBundle.Name != "release name" || releaseBundle.ParsedInfo == nil {
+			t.Errorf("%#v", releaseBundle)
+		}
+	})
+
+	t.Run("import design", func(t *testing.T) {
+		resp, err := post(secondUserToken, apiUrl+"/designs/import", designBundle)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if resp.StatusCode != http.StatusOK {
+			temp, _ := io.ReadAll(resp.Body)
+			t.Error(resp.StatusCode, string(temp))
+			return
+		}
+		result := model.SmartServiceDesign{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Id == design.Id || result.UserId != secondUserId || result.BpmnXml != design.BpmnXml || result.Name != design.Name {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("import release", func(t *testing.T) {
+		resp, err := post(secondUserToken, apiUrl+"/releases/import", releaseBundle)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if resp.StatusCode != http.StatusOK {
+			temp, _ := io.ReadAll(resp.Body)
+			t.Error(resp.StatusCode, string(temp))
+			return
+		}
+		result := model.SmartServiceRelease{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Id == release.Id || result.DesignId == design.Id || result.Creator != secondUserId || result.Name != "release name" {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("import with wrong kind", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPost, secondUserToken, apiUrl+"/designs/import", releaseBundle, http.StatusBadRequest)
+		testRequestStatus(t, http.MethodPost, secondUserToken, apiUrl+"/releases/import", designBundle, http.StatusBadRequest)
+	})
+
+	t.Run("import with modified parsed info", func(t *testing.T) {
+		modified := designBundle
+		info := *designBundle.ParsedInfo
+		info.ParameterDescriptions = slices.Clone(info.ParameterDescriptions)
+		info.ParameterDescriptions[0].Label = "modified"
+		modified.ParsedInfo = &info
+		testRequestStatus(t, http.MethodPost, secondUserToken, apiUrl+"/designs/import", modified, http.StatusBadRequest)
+	})
+
+	t.Run("import invalid", func(t *testing.T) {
+		invalid := designBundle
+		invalid.Version = model.SmartServiceBundleVersion + 1
+		resp, err := post(secondUserToken, apiUrl+"/designs/import", invalid)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if resp.StatusCode != http.StatusBadRequest {
+			temp, _ := io.ReadAll(resp.Body)
+			t.Error(resp.StatusCode, string(temp))
+			return
+		}
+	})
+}
+
+func testExportBundle(t *testing.T, endpoint string) (result model.SmartServiceBundle) {
+	resp, err := get(userToken, endpoint)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		temp, _ := io.ReadAll(resp.Body)
+		t.Error(resp.StatusCode, string(temp))
+		return
+	}
+	checkContentType(t, resp)
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		t.Error(err)
+	}
+	return result
+}
+
+func TestBundleCharacteristicRemapping(t *testing.T) {
+	oldId := "urn:infai:ses:characteristic:0b041ea3-8efd-4ce4-8130-d8af320326a4"
+	newId := "urn:infai:ses:characteristic:mapped"
+	fileContent, err := os.ReadFile("./resources/json_location_input.bpmn")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	bundle := model.SmartServiceBundle{
+		BpmnXml:           string(fileContent),
+		CharacteristicIds: []string{oldId},
+		ParsedInfo: &model.SmartServiceReleaseInfo{
+			ParameterDescriptions: []model.ParameterDescription{{Id: "location", CharacteristicId: &oldId}},
+		},
+	}
+
+	result, err := controller.RemapBundleCharacteristicIds(bundle, map[string]string{oldId: newId})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if strings.Contains(result.BpmnXml, oldId) || !strings.Contains(result.BpmnXml, newId) {
+		t.Error(result.BpmnXml)
+	}
+	if !slices.Equal(result.CharacteristicIds, []string{newId}) {
+		t.Error(result.CharacteristicIds)
+	}
+	if *result.ParsedInfo.ParameterDescriptions[0].CharacteristicId != newId {
+		t.Error(*result.ParsedInfo.ParameterDescriptions[0].CharacteristicId)
+	}
+	if *bundle.ParsedInfo.ParameterDescriptions[0].CharacteristicId != oldId || bundle.BpmnXml != string(fileContent) {
+		t.Error("input bundle was modified")
+	}
+
+	result, err = controller.RemapBundleCharacteristicIds(bundle, nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if result.BpmnXml != bundle.BpmnXml {
+		t.Error("unexpected change without mapping")
+	}
+}
@@ -0,0 +1,158 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/configuration"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/controller"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestLint(t *testing.T) {
+	getCharacteristic := func(id string) (*model.Characteristic, error) {
+		if id == "unknown" {
+			return nil, errors.New("not found")
+		}
+		return &model.Characteristic{Id: id}, nil
+	}
+
+	t.Run("validation resources", func(t *testing.T) {
+		dir := "./resources/validation/"
+		infos, err := os.ReadDir(dir)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		for _, info := range infos {
+			name := info.Name()
+			fileContent, err := os.ReadFile(dir + name)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			diagnostics := controller.LintDesign(configuration.Config{}, string(fileContent), getCharacteristic)
+			if strings.HasPrefix(name, "valid_") && len(diagnostics) > 0 {
+				t.Error(name, diagnostics)
+			}
+			if !strings.HasPrefix(name, "valid_") && len(diagnostics) == 0 {
+				t.Error("expected diagnostics for", name)
+			}
+		}
+	})
+
+	t.Run("params", func(t *testing.T) {
+		diagnostics := controller.LintDesign(configuration.Config{}, resources.ParamsBpmn, getCharacteristic)
+		if len(diagnostics) > 0 {
+			t.Error(diagnostics)
+		}
+	})
+
+	t.Run("invalid xml", func(t *testing.T) {
+		diagnostics := controller.LintDesign(configuration.Config{}, "<bpmn:definitions", getCharacteristic)
+		if len(diagnostics) != 1 || diagnostics[0].Code != "invalid_xml" {
+			t.Error(diagnostics)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		fileContent, err := os.ReadFile("./resources/lint/lint_errors.bpmn")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		diagnostics := controller.LintDesign(configuration.Config{}, string(fileContent), getCharacteristic)
+		actual := []string{}
+		for _, diagnostic := range diagnostics {
+			if diagnostic.Message == "" {
+				t.Error("missing message", diagnostic)
+			}
+			actual = append(actual, strings.Join([]string{diagnostic.Severity, diagnostic.Code, diagnostic.ElementId, diagnostic.FormFieldId}, " "))
+		}
+		expected := []string{
+			"error timer_start_event StartEvent_3 ",
+			"error duplicate_param_id StartEvent_1 valid",
+			"error invalid_criteria StartEvent_1 bad_criteria",
+			"error auto_select_all_without_multiple StartEvent_1 auto_select",
+			"error unknown_characteristic StartEvent_1 characteristic",
+			"error options_characteristic_exclusive StartEvent_1 options_and_characteristic",
+			"error iot_options_exclusive StartEvent_1 iot_and_options",
+			"error iot_characteristic_exclusive StartEvent_1 iot_and_characteristic",
+			"warning ignored_property StartEvent_1 ignored_criteria",
+			"error unknown_same_entity StartEvent_1 dangling",
+			"error duplicate_param_id StartEvent_2 valid",
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("\n%#v\n%#v", actual, expected)
+		}
+	})
+}
+
+func TestLintApi(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, err := apiTestEnv(ctx, wg, false, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		result, ok := request[model.DesignLintResult](t, http.MethodPost, userToken, apiUrl+"/designs/lint", model.SmartServiceDesign{BpmnXml: resources.NamedDescBpmn})
+		if !ok {
+			return
+		}
+		if !result.Valid || len(result.Diagnostics) != 0 {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		result, ok := request[model.DesignLintResult](t, http.MethodPost, userToken, apiUrl+"/designs/lint", model.SmartServiceDesign{BpmnXml: "<bpmn:definitions"})
+		if !ok {
+			return
+		}
+		if result.Valid || len(result.Diagnostics) != 1 || result.Diagnostics[0].Code != "invalid_xml" {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("unknown static path", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPost, userToken, apiUrl+"/designs/unknown", model.SmartServiceDesign{}, http.StatusNotFound)
+		testRequestStatus(t, http.MethodPost, userToken, apiUrl+"/releases/unknown", model.SmartServiceBundle{}, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,279 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/controller"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestDesignDiff(t *testing.T) {
+	changed := strings.Replace(resources.ParamsBpmn, `<camunda:formField id="boolean" type="boolean" />`, `<camunda:formField id="flag" type="boolean" />`, 1)
+	changed = strings.Replace(changed, `label="Device Selection"`, `label="Device"`, 1)
+	changed = strings.Replace(changed, `{&#34;device_class_id&#34;:&#34;foo&#34;}`, `{&#34;device_class_id&#34;:&#34;bar&#34;}`, 1)
+	changed = strings.Replace(changed, `<bpmn:endEvent id="EndEvent_1g4kheh">`, `<bpmn:endEvent id="EndEvent_1g4kheh" name="end">`, 1)
+
+	elements, formFields, err := controller.DiffDesignBpmn(resources.ParamsBpmn, changed)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	expectedElements := model.DesignDiffSection{
+		Added:   []model.DesignDiffEntry{},
+		Removed: []model.DesignDiffEntry{},
+		Changed: []model.DesignDiffEntry{
+			{Id: "EndEvent_1g4kheh", Type: "bpmn:endEvent", Name: "end", Changes: []string{"name"}},
+		},
+	}
+	if !reflect.DeepEqual(elements, expectedElements) {
+		t.Errorf("%#v", elements)
+	}
+	expectedFormFields := model.DesignDiffSection{
+		Added: []model.DesignDiffEntry{
+			{Id: "flag", ParentId: "StartEvent_1", Type: "boolean"},
+		},
+		Removed: []model.DesignDiffEntry{
+			{Id: "boolean", ParentId: "StartEvent_1", Type: "boolean"},
+		},
+		Changed: []model.DesignDiffEntry{
+			{Id: "device", ParentId: "StartEvent_1", Type: "string", Name: "Device", Changes: []string{"label"}},
+			{Id: "group", ParentId: "StartEvent_1", Type: "string", Changes: []string{"property:criteria"}},
+		},
+	}
+	if !reflect.DeepEqual(formFields, expectedFormFields) {
+		t.Errorf("%#v", formFields)
+	}
+
+	elements, formFields, err = controller.DiffDesignBpmn(resources.ParamsBpmn, resources.ParamsBpmn)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(elements.Added)+len(elements.Removed)+len(elements.Changed)+len(formFields.Added)+len(formFields.Removed)+len(formFields.Changed) != 0 {
+		t.Error(elements, formFields)
+	}
+}
+
+func TestDesignRevisions(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, err := apiTestEnv(ctx, wg, false, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	design := model.SmartServiceDesign{}
+	t.Run("create", func(t *testing.T) {
+		resp, err := post(userToken, apiUrl+"/designs", model.SmartServiceDesign{
+			Name:    "first",
+			BpmnXml: resources.ParamsBpmn,
+			SvgXml:  resources.ParamsSvg,
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if resp.StatusCode != http.StatusOK {
+			temp, _ := io.ReadAll(resp.Body)
+			t.Error(resp.StatusCode, string(temp))
+			return
+		}
+		err = json.NewDecoder(resp.Body).Decode(&design)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if design.Revision != 1 {
+			t.Error(design.Revision)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		design.Name = "second"
+		design.BpmnXml = strings.Replace(resources.ParamsBpmn, `label="Device Selection"`, `label="Device"`, 1)
+		resp, err := put(userToken, apiUrl+"/designs/"+url.PathEscape(design.Id), design)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if resp.StatusCode != http.StatusOK {
+			temp, _ := io.ReadAll(resp.Body)
+			t.Error(resp.StatusCode, string(temp))
+			return
+		}
+		err = json.NewDecoder(resp.Body).Decode(&design)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if design.Revision != 2 {
+			t.Error(design.Revision)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		testDesignRevisionList(t, apiUrl, design.Id, []string{"second", "first"})
+	})
+
+	t.Run("get", func(t *testing.T) {
+		resp, err := get(userToken, apiUrl+"/designs/"+url.PathEscape(design.Id)+"/revisions/1")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if resp.StatusCode != http.StatusOK {
+			temp, _ := io.ReadAll(resp.Body)
+			t.Error(resp.StatusCode, string(temp))
+			return
+		}
+		checkContentType(t, resp)
+		result := model.SmartServiceDesignRevision{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Name != "first" || result.BpmnXml != resources.ParamsBpmn || result.Revision != 1 || result.Author != userId {
+			t.Error(result.Name, result.Revision, result.Author)
+		}
+	})
+
+	t.Run("get unknown", func(t *testing.T) {
+		resp, err := get(userToken, apiUrl+"/designs/"+url.PathEscape(design.Id)+"/revisions/42")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if resp.StatusCode != http.StatusNotFound {
+			temp, _ := io.ReadAll(resp.Body)
+			t.Error(resp.StatusCode, string(temp))
+			return
+		}
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		resp, err := get(userToken, apiUrl+"/designs/"+url.PathEscape(design.Id)+"/revisions/1/diff/2")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if resp.StatusCode != http.StatusOK {
+			temp, _ := io.ReadAll(resp.Body)
+			t.Error(resp.StatusCode, string(temp))
+			return
+		}
+		result := model.SmartServiceDesignDiff{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(result.FormFields.Changed) != 1 || result.FormFields.Changed[0].Id != "device" || len(result.Elements.Changed) != 0 {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		resp, err := post(userToken, apiUrl+"/designs/"+url.PathEscape(design.Id)+"/revisions/1/restore", nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if resp.StatusCode != http.StatusOK {
+			temp, _ := io.ReadAll(resp.Body)
+			t.Error(resp.StatusCode, string(temp))
+			return
+		}
+		result := model.SmartServiceDesign{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.Name != "first" || result.BpmnXml != resources.ParamsBpmn || result.Revision != 3 {
+			t.Error(result.Name, result.Revision)
+		}
+	})
+
+	t.Run("list after restore", func(t *testing.T) {
+		testDesignRevisionList(t, apiUrl, design.Id, []string{"first", "second", "first"})
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		resp, err := get(secondUserToken, apiUrl+"/designs/"+url.PathEscape(design.Id)+"/revisions")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if resp.StatusCode == http.StatusOK {
+			t.Error(resp.StatusCode)
+			return
+		}
+	})
+}
+
+func testDesignRevisionList(t *testing.T, apiUrl string, designId string, expectedNamesOrder []string) {
+	resp, err := get(userToken, apiUrl+"/designs/"+url.PathEscape(designId)+"/revisions")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		temp, _ := io.ReadAll(resp.Body)
+		t.Error(resp.StatusCode, string(temp))
+		return
+	}
+	checkContentType(t, resp)
+	result := []model.SmartServiceDesignRevision{}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(result) != len(expectedNamesOrder) {
+		t.Error(len(result), len(expectedNamesOrder))
+		return
+	}
+	for i, element := range result {
+		if element.Name != expectedNamesOrder[i] {
+			t.Error(element.Name, expectedNamesOrder[i])
+		}
+	}
+}
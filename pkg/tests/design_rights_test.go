@@ -0,0 +1,160 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime/debug"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/permissions-v2/pkg/client"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestDesignRights(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, config, _, perm, err := apiTestEnvWithPermClient(ctx, wg, false, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	design := model.SmartServiceDesign{}
+	t.Run("create design", func(t *testing.T) {
+		resp, err := post(userToken, apiUrl+"/designs", model.SmartServiceDesign{
+			BpmnXml: resources.NamedDescBpmn,
+			SvgXml:  resources.NamedDescSvg,
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if resp.StatusCode != http.StatusOK {
+			temp, _ := io.ReadAll(resp.Body)
+			t.Error(resp.StatusCode, string(temp))
+			return
+		}
+		err = json.NewDecoder(resp.Body).Decode(&design)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if design.PermissionsInfo.Shared || !design.PermissionsInfo.Permissions["administrate"] {
+			t.Error(design.PermissionsInfo)
+		}
+	})
+
+	t.Run("second user may not read", func(t *testing.T) {
+		testRequestStatus(t, http.MethodGet, secondUserToken, apiUrl+"/designs/"+url.PathEscape(design.Id), nil, http.StatusForbidden)
+		testDesignList(t, apiUrl, "", []string{design.Name})
+		resp, err := get(secondUserToken, apiUrl+"/designs")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		result := []model.SmartServiceDesign{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(result) != 0 {
+			t.Error(result)
+		}
+	})
+
+	t.Run("share read", func(t *testing.T) {
+		_, err, _ = perm.SetPermission(adminToken, config.SmartServiceDesignPermissionsTopic, design.Id, client.ResourcePermissions{
+			UserPermissions: map[string]client.PermissionsMap{
+				userId:       {Read: true, Write: true, Execute: true, Administrate: true},
+				secondUserId: {Read: true},
+			},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	})
+
+	t.Run("second user may read", func(t *testing.T) {
+		testRequestStatus(t, http.MethodGet, secondUserToken, apiUrl+"/designs/"+url.PathEscape(design.Id), nil, http.StatusOK)
+		testRequestStatus(t, http.MethodPut, secondUserToken, apiUrl+"/designs/"+url.PathEscape(design.Id), design, http.StatusForbidden)
+	})
+
+	t.Run("share write", func(t *testing.T) {
+		_, err, _ = perm.SetPermission(adminToken, config.SmartServiceDesignPermissionsTopic, design.Id, client.ResourcePermissions{
+			UserPermissions: map[string]client.PermissionsMap{
+				userId:       {Read: true, Write: true, Execute: true, Administrate: true},
+				secondUserId: {Read: true, Write: true},
+			},
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	})
+
+	t.Run("second user may write", func(t *testing.T) {
+		design.Name = "changed by second user"
+		resp, err := put(secondUserToken, apiUrl+"/designs/"+url.PathEscape(design.Id), design)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if resp.StatusCode != http.StatusOK {
+			temp, _ := io.ReadAll(resp.Body)
+			t.Error(resp.StatusCode, string(temp))
+			return
+		}
+		result := model.SmartServiceDesign{}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if result.UserId != userId || result.Name != "changed by second user" || !result.PermissionsInfo.Shared {
+			t.Error(result.UserId, result.Name, result.PermissionsInfo)
+		}
+		testDesignList(t, apiUrl, "", []string{"changed by second user"})
+	})
+
+	t.Run("second user may not delete", func(t *testing.T) {
+		testRequestStatus(t, http.MethodDelete, secondUserToken, apiUrl+"/designs/"+url.PathEscape(design.Id), nil, http.StatusForbidden)
+	})
+
+	t.Run("owner may delete", func(t *testing.T) {
+		testRequestStatus(t, http.MethodDelete, userToken, apiUrl+"/designs/"+url.PathEscape(design.Id), nil, http.StatusOK)
+		testDesignList(t, apiUrl, "", []string{})
+	})
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestDesignTemplates(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, err := apiTestEnv(ctx, wg, false, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	containsId := func(list []model.SmartServiceDesign, id string) bool {
+		return slices.ContainsFunc(list, func(design model.SmartServiceDesign) bool {
+			return design.Id == id
+		})
+	}
+
+	template, _ := request[model.SmartServiceDesign](t, http.MethodPost, adminToken, apiUrl+"/designs", model.SmartServiceDesign{
+		Name:    "template",
+		BpmnXml: resources.ParamsBpmn,
+		SvgXml:  resources.ParamsSvg,
+	})
+	templateUrl := apiUrl + "/designs/" + url.PathEscape(template.Id)
+
+	t.Run("only admins may flag templates", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPut, userToken, templateUrl+"/template", true, http.StatusForbidden)
+		testRequestStatus(t, http.MethodGet, secondUserToken, templateUrl, nil, http.StatusForbidden)
+	})
+
+	t.Run("flag template", func(t *testing.T) {
+		result, ok := request[model.SmartServiceDesign](t, http.MethodPut, adminToken, templateUrl+"/template", true)
+		if ok && !result.Template {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("templates are readable by everyone", func(t *testing.T) {
+		templates, ok := request[[]model.SmartServiceDesign](t, http.MethodGet, secondUserToken, apiUrl+"/design-templates", nil)
+		if ok && !containsId(templates, template.Id) {
+			t.Errorf("%#v", templates)
+		}
+		testRequestStatus(t, http.MethodGet, secondUserToken, templateUrl, nil, http.StatusOK)
+		testRequestStatus(t, http.MethodPut, secondUserToken, templateUrl, template, http.StatusForbidden)
+	})
+
+	t.Run("templates are only listed as designs of their owner", func(t *testing.T) {
+		designs, ok := request[[]model.SmartServiceDesign](t, http.MethodGet, adminToken, apiUrl+"/designs", nil)
+		if ok && !containsId(designs, template.Id) {
+			t.Errorf("%#v", designs)
+		}
+		designs, ok = request[[]model.SmartServiceDesign](t, http.MethodGet, secondUserToken, apiUrl+"/designs", nil)
+		if ok && containsId(designs, template.Id) {
+			t.Errorf("%#v", designs)
+		}
+	})
+
+	t.Run("update keeps template flag", func(t *testing.T) {
+		template.Description = "updated"
+		result, ok := request[model.SmartServiceDesign](t, http.MethodPut, adminToken, templateUrl, template)
+		if ok && (!result.Template || result.Description != "updated") {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("clone template", func(t *testing.T) {
+		clone, ok := request[model.SmartServiceDesign](t, http.MethodPost, secondUserToken, templateUrl+"/clone", nil)
+		if !ok {
+			return
+		}
+		if clone.Id == template.Id || clone.UserId != secondUserId || clone.Template || clone.BpmnXml != template.BpmnXml || clone.Name != template.Name {
+			t.Errorf("%#v", clone)
+		}
+		designs, ok := request[[]model.SmartServiceDesign](t, http.MethodGet, secondUserToken, apiUrl+"/designs", nil)
+		if ok && !containsId(designs, clone.Id) {
+			t.Errorf("%#v", designs)
+		}
+	})
+
+	t.Run("release to design", func(t *testing.T) {
+		design, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+			Name:    "design",
+			BpmnXml: resources.ParamsBpmn,
+			SvgXml:  resources.ParamsSvg,
+		})
+		if !ok {
+			return
+		}
+		release, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+			DesignId: design.Id,
+			Name:     "release",
+		})
+		if !ok {
+			return
+		}
+		releaseUrl := apiUrl + "/releases/" + url.PathEscape(release.Id) + "/to-design"
+		testRequestStatus(t, http.MethodPost, secondUserToken, releaseUrl, nil, http.StatusForbidden)
+		result, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, releaseUrl, nil)
+		if ok && (result.Id == design.Id || result.UserId != userId || result.Name != "release" || result.BpmnXml != design.BpmnXml || result.SvgXml != design.SvgXml) {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("unflag template", func(t *testing.T) {
+		result, ok := request[model.SmartServiceDesign](t, http.MethodPut, adminToken, templateUrl+"/template", false)
+		if ok && result.Template {
+			t.Errorf("%#v", result)
+		}
+		testRequestStatus(t, http.MethodGet, secondUserToken, templateUrl, nil, http.StatusForbidden)
+	})
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestInstanceDryRun(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, err := apiTestEnv(ctx, wg, true, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	design, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+		BpmnXml: resources.ProcessDeploymentBpmn,
+		SvgXml:  resources.ProcessDeploymentSvg,
+	})
+	if !ok {
+		return
+	}
+	release, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+		DesignId: design.Id,
+		Name:     "release name",
+	})
+	if !ok {
+		return
+	}
+
+	time.Sleep(5 * time.Second) //allow async cqrs
+
+	parameters, ok := request[[]model.SmartServiceExtendedParameter](t, http.MethodGet, userToken, apiUrl+"/releases/"+url.PathEscape(release.Id)+"/parameters", nil)
+	if !ok {
+		return
+	}
+	instanceParameters := fillTestParameter(parameters)
+	longColor := strings.Repeat("f", 3000)
+	for i, param := range instanceParameters {
+		if param.Id == "color_hex" {
+			instanceParameters[i].Value = longColor
+		}
+	}
+	dryRunUrl := apiUrl + "/releases/" + url.PathEscape(release.Id) + "/instances?dry_run=true"
+
+	t.Run("start form", func(t *testing.T) {
+		form, ok := request[model.CamundaStartForm](t, http.MethodPost, userToken, dryRunUrl, model.SmartServiceInstanceInit{
+			SmartServiceInstanceInfo: model.SmartServiceInstanceInfo{Name: "dry run"},
+			Parameters:               instanceParameters,
+		})
+		if !ok {
+			return
+		}
+		if form.BusinessKey == "" {
+			t.Errorf("%#v", form)
+		}
+		if form.Variables["color_hex"].Value != "{{.color_hex}}" {
+			t.Errorf("%#v", form.Variables["color_hex"])
+		}
+		if _, ok := form.Variables["device_selection"]; !ok {
+			t.Errorf("%#v", form.Variables)
+		}
+	})
+
+	t.Run("invalid parameter", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPost, userToken, dryRunUrl, model.SmartServiceInstanceInit{
+			SmartServiceInstanceInfo: model.SmartServiceInstanceInfo{Name: "dry run"},
+			Parameters:               append(instanceParameters, model.SmartServiceParameter{Id: "unknown", Value: "foo"}),
+		}, http.StatusBadRequest)
+	})
+
+	t.Run("missing release access", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPost, secondUserToken, dryRunUrl, model.SmartServiceInstanceInit{
+			SmartServiceInstanceInfo: model.SmartServiceInstanceInfo{Name: "dry run"},
+			Parameters:               instanceParameters,
+		}, http.StatusForbidden)
+	})
+
+	t.Run("nothing persisted", func(t *testing.T) {
+		instances, ok := request[[]model.SmartServiceInstance](t, http.MethodGet, userToken, apiUrl+"/instances", nil)
+		if ok && len(instances) != 0 {
+			t.Errorf("%#v", instances)
+		}
+	})
+}
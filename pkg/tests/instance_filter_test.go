@@ -0,0 +1,135 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestInstanceFilter(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, err := apiTestEnv(ctx, wg, true, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	design, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+		BpmnXml: resources.ProcessDeploymentBpmn,
+		SvgXml:  resources.ProcessDeploymentSvg,
+	})
+	if !ok {
+		return
+	}
+	release, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+		DesignId: design.Id,
+		Name:     "release name",
+	})
+	if !ok {
+		return
+	}
+
+	time.Sleep(5 * time.Second) //allow async cqrs
+
+	parameters, ok := request[[]model.SmartServiceExtendedParameter](t, http.MethodGet, userToken, apiUrl+"/releases/"+url.PathEscape(release.Id)+"/parameters", nil)
+	if !ok {
+		return
+	}
+	createInstance := func(name string, description string) model.SmartServiceInstance {
+		instance, _ := request[model.SmartServiceInstance](t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(release.Id)+"/instances", model.SmartServiceInstanceInit{
+			SmartServiceInstanceInfo: model.SmartServiceInstanceInfo{Name: name, Description: description},
+			Parameters:               fillTestParameter(parameters),
+		})
+		return instance
+	}
+
+	heating := createInstance("heating control", "controls the radiators")
+	time.Sleep(1100 * time.Millisecond) //created_at has a resolution of seconds
+	light := createInstance("light schedule", "switches the lamps")
+	if t.Failed() {
+		return
+	}
+	testRequestStatus(t, http.MethodPut, userToken, apiUrl+"/instances/"+url.PathEscape(light.Id)+"/error", "test error", http.StatusOK)
+
+	checkList := func(query string, expectedIds ...string) {
+		t.Run("list "+query, func(t *testing.T) {
+			instances, ok := request[[]model.SmartServiceInstance](t, http.MethodGet, userToken, apiUrl+"/instances?"+query, nil)
+			if !ok {
+				return
+			}
+			actualIds := map[string]bool{}
+			for _, instance := range instances {
+				actualIds[instance.Id] = true
+			}
+			if len(actualIds) != len(expectedIds) {
+				t.Error(actualIds, expectedIds)
+				return
+			}
+			for _, id := range expectedIds {
+				if !actualIds[id] {
+					t.Error(actualIds, expectedIds)
+					return
+				}
+			}
+		})
+	}
+
+	checkList("", heating.Id, light.Id)
+	checkList("search=heating", heating.Id)
+	checkList("search=lamps", light.Id)
+	checkList("search=unknown")
+	checkList("design_id="+url.QueryEscape(design.Id), heating.Id, light.Id)
+	checkList("design_id=unknown")
+	checkList("error=true", light.Id)
+	checkList("error=false", heating.Id)
+	checkList("deleting=false", heating.Id, light.Id)
+	checkList("outdated=true")
+	checkList("outdated=false", heating.Id, light.Id)
+	checkList("created_before="+strconv.FormatInt(light.CreatedAt, 10), heating.Id)
+	checkList("created_after="+strconv.FormatInt(heating.CreatedAt, 10), light.Id)
+	checkList("updated_after=" + strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+	checkList("shared=false", heating.Id, light.Id)
+	checkList("shared=true")
+	checkList("search=control&error=false", heating.Id)
+
+	t.Run("invalid filter", func(t *testing.T) {
+		testRequestStatus(t, http.MethodGet, userToken, apiUrl+"/instances?outdated=foo", nil, http.StatusBadRequest)
+		testRequestStatus(t, http.MethodGet, userToken, apiUrl+"/instances?created_after=foo", nil, http.StatusBadRequest)
+	})
+}
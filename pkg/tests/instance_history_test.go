@@ -0,0 +1,153 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"runtime/debug"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestInstanceHistory(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, err := apiTestEnv(ctx, wg, true, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	design, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+		BpmnXml: resources.ProcessDeploymentBpmn,
+		SvgXml:  resources.ProcessDeploymentSvg,
+	})
+	if !ok {
+		return
+	}
+	release, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+		DesignId: design.Id,
+		Name:     "release name",
+	})
+	if !ok {
+		return
+	}
+
+	time.Sleep(5 * time.Second) //allow async cqrs
+
+	parameters, ok := request[[]model.SmartServiceExtendedParameter](t, http.MethodGet, userToken, apiUrl+"/releases/"+url.PathEscape(release.Id)+"/parameters", nil)
+	if !ok {
+		return
+	}
+	instance, ok := request[model.SmartServiceInstance](t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(release.Id)+"/instances", model.SmartServiceInstanceInit{
+		SmartServiceInstanceInfo: model.SmartServiceInstanceInfo{Name: "instance"},
+		Parameters:               fillTestParameter(parameters),
+	})
+	if !ok {
+		return
+	}
+	instanceUrl := apiUrl + "/instances/" + url.PathEscape(instance.Id)
+
+	_, ok = request[model.SmartServiceInstance](t, http.MethodPut, userToken, instanceUrl+"/info", model.SmartServiceInstanceInfo{Name: "renamed", Description: "desc"})
+	if !ok {
+		return
+	}
+	_, ok = request[model.SmartServiceInstanceVariable](t, http.MethodPut, userToken, instanceUrl+"/variables/foo", 42)
+	if !ok {
+		return
+	}
+	testRequestStatus(t, http.MethodDelete, userToken, instanceUrl+"/variables/foo", nil, http.StatusOK)
+	testRequestStatus(t, http.MethodPut, userToken, instanceUrl+"/error", "test error", http.StatusOK)
+
+	t.Run("missing read access", func(t *testing.T) {
+		testRequestStatus(t, http.MethodGet, secondUserToken, instanceUrl+"/history", nil, http.StatusForbidden)
+	})
+
+	t.Run("history", func(t *testing.T) {
+		events, ok := request[[]model.SmartServiceInstanceEvent](t, http.MethodGet, userToken, instanceUrl+"/history", nil)
+		if !ok {
+			return
+		}
+		expectedTypes := []string{
+			model.InstanceEventCreated,
+			model.InstanceEventInfoUpdated,
+			model.InstanceEventVariablesSet,
+			model.InstanceEventVariableDeleted,
+			model.InstanceEventError,
+		}
+		if len(events) != len(expectedTypes) {
+			t.Errorf("%#v", events)
+			return
+		}
+		for i, event := range events {
+			if event.Type != expectedTypes[i] || event.InstanceId != instance.Id || event.Actor.UserId != userId {
+				t.Errorf("%v %#v", i, event)
+			}
+		}
+		if events[0].ReleaseId != release.Id {
+			t.Error(events[0].ReleaseId)
+		}
+		if events[1].Name != "renamed" || events[1].Description != "desc" {
+			t.Errorf("%#v", events[1])
+		}
+		if len(events[2].VariableNames) != 1 || events[2].VariableNames[0] != "foo" {
+			t.Errorf("%#v", events[2])
+		}
+		if events[4].Error != "test error" {
+			t.Errorf("%#v", events[4])
+		}
+	})
+
+	t.Run("paginated history", func(t *testing.T) {
+		events, ok := request[[]model.SmartServiceInstanceEvent](t, http.MethodGet, userToken, instanceUrl+"/history?limit=2&offset=1&sort=time.desc", nil)
+		if !ok {
+			return
+		}
+		if len(events) != 2 || events[0].Type != model.InstanceEventVariableDeleted || events[1].Type != model.InstanceEventVariablesSet {
+			t.Errorf("%#v", events)
+		}
+	})
+
+	testRequestStatus(t, http.MethodDelete, userToken, instanceUrl, nil, http.StatusOK)
+
+	t.Run("history after delete", func(t *testing.T) {
+		events, ok := request[[]model.SmartServiceInstanceEvent](t, http.MethodGet, adminToken, instanceUrl+"/history", nil)
+		if !ok {
+			return
+		}
+		if len(events) != 6 || events[5].Type != model.InstanceEventDeleted || events[5].Actor.UserId != userId {
+			t.Errorf("%#v", events)
+		}
+	})
+}
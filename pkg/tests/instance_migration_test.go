@@ -0,0 +1,186 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"runtime/debug"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/controller"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestInstanceMigrationParameters(t *testing.T) {
+	from := model.SmartServiceReleaseInfo{
+		ParameterDescriptions: []model.ParameterDescription{
+			{Id: "unchanged", Label: "Unchanged", Type: "string"},
+			{Id: "label", Label: "Label", Type: "string"},
+			{Id: "type", Label: "Type", Type: "string"},
+			{Id: "removed", Label: "Removed", Type: "string"},
+			{Id: "auto", Label: "Auto", Type: "string", AutoSelectAll: true, Multiple: true},
+		},
+	}
+	to := model.SmartServiceReleaseInfo{
+		ParameterDescriptions: []model.ParameterDescription{
+			{Id: "unchanged", Label: "Unchanged", Type: "string"},
+			{Id: "label", Label: "Label 2", Type: "string"},
+			{Id: "type", Label: "Type", Type: "long"},
+			{Id: "auto", Label: "Auto", Type: "string", AutoSelectAll: true, Multiple: true},
+			{Id: "default", Label: "Default", Type: "string", DefaultValue: "foo"},
+			{Id: "optional", Label: "Optional", Type: "string", Optional: true},
+		},
+	}
+	parameters := []model.SmartServiceParameter{
+		{Id: "unchanged", Label: "Unchanged", Value: "a"},
+		{Id: "label", Label: "Label", Value: "b"},
+		{Id: "type", Label: "Type", Value: "c"},
+		{Id: "removed", Label: "Removed", Value: "d"},
+	}
+
+	result, missing := controller.MigrateInstanceParameters(parameters, from, to)
+	expected := []model.SmartServiceParameter{
+		{Id: "unchanged", Label: "Unchanged", Value: "a"},
+		{Id: "label", Label: "Label 2", Value: "b"},
+		{Id: "default", Label: "Default", Value: "foo"},
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("%#v", result)
+	}
+	if !reflect.DeepEqual(missing, []string{"type"}) {
+		t.Errorf("%#v", missing)
+	}
+
+	result, missing = controller.MigrateInstanceParameters(parameters[:1], from, from)
+	if !reflect.DeepEqual(result, parameters[:1]) {
+		t.Errorf("%#v", result)
+	}
+	if !reflect.DeepEqual(missing, []string{"label", "type", "removed"}) {
+		t.Errorf("%#v", missing)
+	}
+}
+
+func TestInstanceMigration(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, err := apiTestEnv(ctx, wg, true, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	design, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+		Name:    "design",
+		BpmnXml: resources.NamedDescBpmn,
+		SvgXml:  resources.NamedDescSvg,
+	})
+	if !ok {
+		return
+	}
+	first, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+		DesignId: design.Id,
+		Name:     "first",
+	})
+	if !ok {
+		return
+	}
+	time.Sleep(time.Second)
+
+	instance, ok := request[model.SmartServiceInstance](t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(first.Id)+"/instances", model.SmartServiceInstanceInit{
+		SmartServiceInstanceInfo: model.SmartServiceInstanceInfo{Name: "instance"},
+		Parameters:               []model.SmartServiceParameter{},
+	})
+	if !ok {
+		return
+	}
+	time.Sleep(time.Second)
+
+	second, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+		DesignId: design.Id,
+		Name:     "second",
+	})
+	if !ok {
+		return
+	}
+	time.Sleep(time.Second)
+
+	t.Run("missing rights", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPost, secondUserToken, apiUrl+"/releases/"+url.PathEscape(second.Id)+"/migrate-instances", nil, http.StatusForbidden)
+	})
+
+	job := model.InstanceMigrationJob{}
+	t.Run("migrate", func(t *testing.T) {
+		job, ok = request[model.InstanceMigrationJob](t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(second.Id)+"/migrate-instances", nil)
+		if !ok {
+			return
+		}
+		if len(job.Results) != 1 || job.Results[0].InstanceId != instance.Id || job.Results[0].PreviousReleaseId != first.Id {
+			t.Errorf("%#v", job)
+		}
+		for i := 0; i < 20 && job.Status != model.InstanceMigrationJobStatusFinished; i++ {
+			time.Sleep(time.Second)
+			job, ok = request[model.InstanceMigrationJob](t, http.MethodGet, userToken, apiUrl+"/releases/"+url.PathEscape(second.Id)+"/migrate-instances/"+url.PathEscape(job.Id), nil)
+			if !ok {
+				return
+			}
+		}
+		if job.Status != model.InstanceMigrationJobStatusFinished || len(job.Results) != 1 || job.Results[0].Status != model.InstanceMigrationStatusMigrated {
+			t.Errorf("%#v", job)
+		}
+	})
+
+	t.Run("check instance", func(t *testing.T) {
+		result, ok := request[model.SmartServiceInstance](t, http.MethodGet, userToken, apiUrl+"/instances/"+url.PathEscape(instance.Id), nil)
+		if ok && (result.ReleaseId != second.Id || result.NewReleaseId != "") {
+			t.Errorf("%#v", result)
+		}
+	})
+
+	t.Run("list jobs", func(t *testing.T) {
+		jobs, ok := request[[]model.InstanceMigrationJob](t, http.MethodGet, userToken, apiUrl+"/releases/"+url.PathEscape(second.Id)+"/migrate-instances", nil)
+		if ok && (len(jobs) != 1 || jobs[0].Id != job.Id) {
+			t.Errorf("%#v", jobs)
+		}
+		jobs, ok = request[[]model.InstanceMigrationJob](t, http.MethodGet, adminToken, apiUrl+"/releases/"+url.PathEscape(second.Id)+"/migrate-instances", nil)
+		if ok && len(jobs) != 1 {
+			t.Errorf("%#v", jobs)
+		}
+	})
+
+	t.Run("nothing to migrate", func(t *testing.T) {
+		result, ok := request[model.InstanceMigrationJob](t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(second.Id)+"/migrate-instances", nil)
+		if ok && (result.Status != model.InstanceMigrationJobStatusFinished || len(result.Results) != 0) {
+			t.Errorf("%#v", result)
+		}
+	})
+}
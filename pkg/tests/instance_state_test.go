@@ -0,0 +1,127 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+)
+
+func TestInstanceState(t *testing.T) {
+	t.Run("transitions", func(t *testing.T) {
+		instance := model.SmartServiceInstance{}
+		steps := []struct {
+			state   string
+			allowed bool
+		}{
+			{state: model.InstanceStateReady, allowed: false},
+			{state: model.InstanceStateCreating, allowed: true},
+			{state: model.InstanceStateStarting, allowed: true},
+			{state: model.InstanceStateCreating, allowed: false},
+			{state: model.InstanceStateReady, allowed: true},
+			{state: model.InstanceStateMaintenance, allowed: true},
+			{state: model.InstanceStateReady, allowed: true},
+			{state: model.InstanceStateStarting, allowed: false},
+			{state: model.InstanceStateError, allowed: true},
+			{state: model.InstanceStateStarting, allowed: true},
+			{state: model.InstanceStateStopped, allowed: true},
+			{state: model.InstanceStateDeleting, allowed: true},
+			{state: model.InstanceStateReady, allowed: false},
+			{state: model.InstanceStateError, allowed: true},
+			{state: "foo", allowed: false},
+		}
+		for _, step := range steps {
+			before := instance.State
+			err := instance.SetState(step.state)
+			if step.allowed && err != nil {
+				t.Error(before, step.state, err)
+				return
+			}
+			if !step.allowed && err == nil {
+				t.Error("expected error", before, step.state)
+				return
+			}
+			if !step.allowed && instance.State != before {
+				t.Error(before, instance.State)
+				return
+			}
+		}
+	})
+
+	t.Run("timestamp", func(t *testing.T) {
+		instance := model.SmartServiceInstance{}
+		err := instance.SetState(model.InstanceStateCreating)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if instance.StateChangedAt == 0 {
+			t.Error(instance.StateChangedAt)
+		}
+		instance.StateChangedAt = 42
+		err = instance.SetState(model.InstanceStateCreating)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if instance.StateChangedAt != 42 {
+			t.Error("setting the current state should not change state_changed_at", instance.StateChangedAt)
+		}
+		err = instance.SetState(model.InstanceStateStarting)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if instance.StateChangedAt == 42 {
+			t.Error(instance.StateChangedAt)
+		}
+	})
+
+	t.Run("every state has transitions", func(t *testing.T) {
+		for _, state := range model.InstanceStates {
+			if len(model.InstanceStateTransitions[state]) == 0 {
+				t.Error(state)
+			}
+			for _, target := range model.InstanceStateTransitions[state] {
+				if target == model.InstanceStateCreating {
+					t.Error("only new instances may be creating", state)
+				}
+			}
+		}
+	})
+
+	t.Run("legacy instances", func(t *testing.T) {
+		cases := []struct {
+			instance model.SmartServiceInstance
+			expected string
+		}{
+			{instance: model.SmartServiceInstance{}, expected: model.InstanceStateStarting},
+			{instance: model.SmartServiceInstance{Ready: true}, expected: model.InstanceStateReady},
+			{instance: model.SmartServiceInstance{Ready: true, RunningMaintenanceIds: []string{"m"}}, expected: model.InstanceStateMaintenance},
+			{instance: model.SmartServiceInstance{Ready: true, Error: "foo"}, expected: model.InstanceStateError},
+			{instance: model.SmartServiceInstance{Error: model.ErrMissingCamundaProcessInstance}, expected: model.InstanceStateStopped},
+			{instance: model.SmartServiceInstance{Deleting: true, Error: "foo"}, expected: model.InstanceStateDeleting},
+			{instance: model.SmartServiceInstance{Ready: true, State: model.InstanceStateError}, expected: model.InstanceStateError},
+		}
+		for i, c := range cases {
+			if actual := c.instance.GetState(); actual != c.expected {
+				t.Error(i, actual, c.expected)
+			}
+		}
+	})
+}
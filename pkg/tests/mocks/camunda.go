@@ -32,10 +32,26 @@ func (this *CamundaErrMock) RemoveRelease(id string) error {
 	return this.Err
 }
 
+func (this *CamundaErrMock) IsReleaseDeployed(id string) (bool, error) {
+	return this.Err == nil, this.Err
+}
+
+func (this *CamundaErrMock) GetDeployedReleaseKeys() (keys []string, err error) {
+	return nil, this.Err
+}
+
+func (this *CamundaErrMock) ReleaseIdToKey(id string) string {
+	return id
+}
+
 func (this *CamundaErrMock) Start(result model.SmartServiceInstance) error {
 	return this.Err
 }
 
+func (this *CamundaErrMock) GetStartForm(instance model.SmartServiceInstance) (model.CamundaStartForm, error) {
+	return model.CamundaStartForm{}, this.Err
+}
+
 func (this *CamundaErrMock) CheckInstanceReady(smartServiceInstanceId string) (finished bool, missing bool, err error) {
 	//TODO implement me
 	panic("implement me")
@@ -0,0 +1,180 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestOptimisticConcurrency(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, err := apiTestEnv(ctx, wg, true, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	design, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+		Name:    "design",
+		BpmnXml: resources.NamedDescBpmn,
+		SvgXml:  resources.NamedDescSvg,
+	})
+	if !ok {
+		return
+	}
+	designUrl := apiUrl + "/designs/" + url.PathEscape(design.Id)
+
+	t.Run("design", func(t *testing.T) {
+		etag := testGetETag(t, designUrl)
+		if etag != `"`+strconv.FormatInt(design.Revision, 10)+`"` {
+			t.Error(etag, design.Revision)
+			return
+		}
+		design.Name = "tab 1"
+		newETag := testPutWithIfMatch(t, designUrl, etag, design, http.StatusOK)
+		if newETag == "" || newETag == etag {
+			t.Error(newETag, etag)
+		}
+		design.Name = "tab 2"
+		testPutWithIfMatch(t, designUrl, etag, design, http.StatusPreconditionFailed)
+		testPutWithIfMatch(t, designUrl, "invalid", design, http.StatusPreconditionFailed)
+		testPutWithIfMatch(t, designUrl, newETag, design, http.StatusOK)
+		testPutWithIfMatch(t, designUrl, "", design, http.StatusOK) //no If-Match --> last write wins
+		testPutWithIfMatch(t, apiUrl+"/designs/unknown", etag, design, http.StatusBadRequest)
+
+		current, ok := request[model.SmartServiceDesign](t, http.MethodGet, userToken, designUrl, nil)
+		if !ok {
+			return
+		}
+		revisions, ok := request[[]model.SmartServiceDesignRevision](t, http.MethodGet, userToken, designUrl+"/revisions", nil)
+		if !ok {
+			return
+		}
+		for _, revision := range revisions {
+			if revision.Revision > current.Revision {
+				t.Error("revision without design update", revision.Revision, current.Revision)
+			}
+		}
+	})
+
+	release, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+		DesignId: design.Id,
+		Name:     "release",
+	})
+	if !ok {
+		return
+	}
+	time.Sleep(2 * time.Second)
+	instance, ok := request[model.SmartServiceInstance](t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(release.Id)+"/instances", model.SmartServiceInstanceInit{
+		SmartServiceInstanceInfo: model.SmartServiceInstanceInfo{Name: "instance"},
+		Parameters:               []model.SmartServiceParameter{},
+	})
+	if !ok {
+		return
+	}
+	instanceUrl := apiUrl + "/instances/" + url.PathEscape(instance.Id)
+
+	t.Run("instance info", func(t *testing.T) {
+		etag := testGetETag(t, instanceUrl)
+		if etag == "" {
+			t.Error("missing etag")
+			return
+		}
+		newETag := testPutWithIfMatch(t, instanceUrl+"/info", etag, model.SmartServiceInstanceInfo{Name: "tab 1"}, http.StatusOK)
+		if newETag == "" || newETag == etag {
+			t.Error(newETag, etag)
+		}
+		testPutWithIfMatch(t, instanceUrl+"/info", etag, model.SmartServiceInstanceInfo{Name: "tab 2"}, http.StatusPreconditionFailed)
+		testPutWithIfMatch(t, instanceUrl+"/info", newETag, model.SmartServiceInstanceInfo{Name: "tab 2"}, http.StatusOK)
+
+		result, ok := request[model.SmartServiceInstance](t, http.MethodGet, userToken, instanceUrl, nil)
+		if ok && result.Name != "tab 2" {
+			t.Error(result.Name)
+		}
+	})
+}
+
+func testGetETag(t *testing.T, endpoint string) string {
+	t.Helper()
+	resp, err := get(userToken, endpoint)
+	if err != nil {
+		t.Error(err)
+		return ""
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		temp, _ := io.ReadAll(resp.Body)
+		t.Error(resp.StatusCode, string(temp))
+		return ""
+	}
+	return resp.Header.Get("ETag")
+}
+
+func testPutWithIfMatch(t *testing.T, endpoint string, ifMatch string, msg interface{}, expectedStatus int) (etag string) {
+	t.Helper()
+	body := new(bytes.Buffer)
+	err := json.NewEncoder(body).Encode(msg)
+	if err != nil {
+		t.Error(err)
+		return ""
+	}
+	req, err := http.NewRequest(http.MethodPut, endpoint, body)
+	if err != nil {
+		t.Error(err)
+		return ""
+	}
+	req.Header.Set("Authorization", userToken)
+	req.Header.Set("Content-Type", "application/json")
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Error(err)
+		return ""
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != expectedStatus {
+		temp, _ := io.ReadAll(resp.Body)
+		t.Error(ifMatch, resp.StatusCode, string(temp))
+	}
+	return resp.Header.Get("ETag")
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/controller"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestSchema(t *testing.T) {
+	t.Run("expected params 1", func(t *testing.T) {
+		params := []model.SmartServiceExtendedParameter{}
+		err := json.Unmarshal(resources.ExpectedParams1, &params)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		schema := controller.ParametersToJsonSchema("test", "", params)
+		if schema.Schema != model.JsonSchemaDraft || schema.Type != "object" || schema.Title != "test" {
+			t.Errorf("%#v", schema)
+		}
+		if len(schema.Properties) != len(params) || len(schema.Required) != len(params) {
+			t.Errorf("%#v", schema)
+			return
+		}
+		if p := schema.Properties["device"]; p.Type != "string" || len(p.Enum) != 16 || len(p.EnumLabels) != 16 || p.Title != "Device Selection" || p.Description != "select a device" {
+			t.Errorf("%#v", p)
+		}
+		if p := schema.Properties["freeNumDefault"]; p.Type != "integer" || p.Enum != nil || p.Default != float64(42) {
+			t.Errorf("%#v", p)
+		}
+		if p := schema.Properties["freeStrDefault"]; p.Type != "string" || p.Default != "default string" {
+			t.Errorf("%#v", p)
+		}
+		if p := schema.Properties["boolean"]; p.Type != "boolean" {
+			t.Errorf("%#v", p)
+		}
+		if p := schema.Properties["multiple"]; p.Type != "array" || p.Items == nil || p.Items.Type != "integer" {
+			t.Errorf("%#v", p)
+		}
+		if p := schema.Properties["devices_multiple"]; p.Type != "array" || p.Items == nil || p.Items.Type != "string" || len(p.Items.Enum) != 16 || p.Enum != nil {
+			t.Errorf("%#v", p)
+		}
+	})
+
+	t.Run("optional and defaults", func(t *testing.T) {
+		schema := controller.ParametersToJsonSchema("", "", []model.SmartServiceExtendedParameter{
+			{
+				SmartServiceParameter: model.SmartServiceParameter{Id: "a", Label: "A"},
+				Type:                  model.Float,
+				Optional:              true,
+				DefaultValue:          1.5,
+				Order:                 2,
+			},
+			{
+				SmartServiceParameter: model.SmartServiceParameter{Id: "b", Label: "B"},
+				Type:                  model.String,
+				Multiple:              true,
+				DefaultValue:          "x",
+				Options:               []model.Option{{Value: "x", Label: "X"}, {Value: "y", Label: "Y"}},
+				Order:                 1,
+			},
+			{
+				SmartServiceParameter: model.SmartServiceParameter{Id: "c", Label: "C"},
+				Type:                  "https://schema.org/Unknown",
+			},
+		})
+		if !reflect.DeepEqual(schema.Required, []string{"b", "c"}) {
+			t.Error(schema.Required)
+		}
+		if schema.AdditionalProperties == nil || *schema.AdditionalProperties {
+			t.Error(schema.AdditionalProperties)
+		}
+		a := schema.Properties["a"]
+		if a.Type != "number" || a.Default != 1.5 || a.Order == nil || *a.Order != 2 {
+			t.Errorf("%#v", a)
+		}
+		b := schema.Properties["b"]
+		if b.Type != "array" || !reflect.DeepEqual(b.Default, []interface{}{"x"}) || b.Items == nil || !reflect.DeepEqual(b.Items.Enum, []interface{}{"x", "y"}) || !reflect.DeepEqual(b.Items.EnumLabels, []string{"X", "Y"}) {
+			t.Errorf("%#v", b)
+		}
+		if c := schema.Properties["c"]; c.Type != "" {
+			t.Errorf("%#v", c)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		schema := controller.ParametersToJsonSchema("", "", []model.SmartServiceExtendedParameter{{
+			SmartServiceParameter: model.SmartServiceParameter{Id: "a", Label: "A"},
+			Type:                  model.Boolean,
+		}})
+		actual, err := json.Marshal(schema)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","properties":{"a":{"title":"A","type":"boolean","x-order":0}},"required":["a"],"additionalProperties":false}`
+		if string(actual) != expected {
+			t.Error(string(actual))
+		}
+	})
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/controller"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+)
+
+func TestParameterValidation(t *testing.T) {
+	descriptions := []model.ParameterDescription{
+		{Id: "name", Type: "string"},
+		{Id: "count", Type: "long", DefaultValue: float64(1)},
+		{Id: "enabled", Type: "boolean", Optional: true},
+		{Id: "mode", Type: "string", Optional: true, Options: map[string]interface{}{"Fast": "fast", "Slow": "slow"}},
+		{Id: "levels", Type: "long", Optional: true, Multiple: true, Options: map[string]interface{}{"One": 1, "Two": 2}},
+		{Id: "device", Type: "string", Optional: true, IotDescription: &model.IotDescription{}},
+		{Id: "service", Type: "string", Optional: true, IotDescription: &model.IotDescription{NeedsSameEntityIdInParameter: "device"}},
+		{Id: "all", Type: "string", Multiple: true, AutoSelectAll: true, IotDescription: &model.IotDescription{}},
+	}
+	options := map[string][]model.Option{
+		"mode":   {{Value: "fast", Label: "Fast"}, {Value: "slow", Label: "Slow"}},
+		"levels": {{Value: 1, Label: "One"}, {Value: 2, Label: "Two"}},
+		"device": {
+			{Value: `{"device_selection":{"device_id":"d1","service_id":null,"path":null},"label":"Device 1"}`, Label: "Device 1", EntityId: "d1"},
+			{Value: `{"device_selection":{"device_id":"d2","service_id":null,"path":null},"label":"Device 2"}`, Label: "Device 2", EntityId: "d2"},
+		},
+		"service": {
+			{Value: `{"device_selection":{"device_id":"d1","service_id":"s1","path":null},"label":"Device 1 / S1"}`, Label: "Device 1 / S1", EntityId: "d1", NeedsSameEntityIdInParameter: "device"},
+			{Value: `{"device_selection":{"device_id":"d2","service_id":"s1","path":null},"label":"Device 2 / S1"}`, Label: "Device 2 / S1", EntityId: "d2", NeedsSameEntityIdInParameter: "device"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		parameters []model.SmartServiceParameter
+		valid      bool
+	}{
+		{
+			name:       "minimal",
+			parameters: []model.SmartServiceParameter{{Id: "name", Value: "foo"}},
+			valid:      true,
+		},
+		{
+			name: "complete",
+			parameters: []model.SmartServiceParameter{
+				{Id: "name", Value: map[string]interface{}{"Latitude": 51.3, "Longitude": 12.4}},
+				{Id: "count", Value: float64(3)},
+				{Id: "enabled", Value: true},
+				{Id: "mode", Value: "slow"},
+				{Id: "levels", Value: []interface{}{float64(1), float64(2)}},
+				{Id: "device", Value: `{"device_selection":{"device_id":"d2","service_id":null,"path":null},"label":"renamed device"}`},
+				{Id: "service", Value: `{"device_selection":{"device_id":"d2","service_id":"s1","path":null},"label":"Device 2 / S1"}`},
+			},
+			valid: true,
+		},
+		{
+			name:       "empty optional value",
+			parameters: []model.SmartServiceParameter{{Id: "name", Value: "foo"}, {Id: "mode", Value: ""}},
+			valid:      true,
+		},
+		{
+			name:       "missing required",
+			parameters: []model.SmartServiceParameter{{Id: "count", Value: float64(3)}},
+		},
+		{
+			name:       "nil required",
+			parameters: []model.SmartServiceParameter{{Id: "name", Value: nil}},
+		},
+		{
+			name:       "unknown id",
+			parameters: []model.SmartServiceParameter{{Id: "name", Value: "foo"}, {Id: "unknown", Value: "bar"}},
+		},
+		{
+			name:       "duplicate id",
+			parameters: []model.SmartServiceParameter{{Id: "name", Value: "foo"}, {Id: "name", Value: "bar"}},
+		},
+		{
+			name:       "auto select all",
+			parameters: []model.SmartServiceParameter{{Id: "name", Value: "foo"}, {Id: "all", Value: []interface{}{}}},
+		},
+		{
+			name:       "string type mismatch",
+			parameters: []model.SmartServiceParameter{{Id: "name", Value: float64(42)}},
+		},
+		{
+			name:       "long type mismatch",
+			parameters: []model.SmartServiceParameter{{Id: "name", Value: "foo"}, {Id: "count", Value: "3"}},
+		},
+		{
+			name:       "long fraction",
+			parameters: []model.SmartServiceParameter{{Id: "name", Value: "foo"}, {Id: "count", Value: 1.5}},
+		},
+		{
+			name:       "boolean type mismatch",
+			parameters: []model.SmartServiceParameter{{Id: "name", Value: "foo"}, {Id: "enabled", Value: "true"}},
+		},
+		{
+			name:       "list for single value",
+			parameters: []model.SmartServiceParameter{{Id: "name", Value: []interface{}{"foo"}}},
+		},
+		{
+			name:       "single value for multiple",
+			parameters: []model.SmartServiceParameter{{Id: "name", Value: "foo"}, {Id: "levels", Value: float64(1)}},
+		},
+		{
+			name:       "unknown option",
+			parameters: []model.SmartServiceParameter{{Id: "name", Value: "foo"}, {Id: "mode", Value: "medium"}},
+		},
+		{
+			name:       "unknown option in list",
+			parameters: []model.SmartServiceParameter{{Id: "name", Value: "foo"}, {Id: "levels", Value: []interface{}{float64(1), float64(3)}}},
+		},
+		{
+			name:       "inaccessible iot option",
+			parameters: []model.SmartServiceParameter{{Id: "name", Value: "foo"}, {Id: "device", Value: `{"device_selection":{"device_id":"d3","service_id":null,"path":null},"label":"Device 3"}`}},
+		},
+		{
+			name: "different entity",
+			parameters: []model.SmartServiceParameter{
+				{Id: "name", Value: "foo"},
+				{Id: "device", Value: `{"device_selection":{"device_id":"d1","service_id":null,"path":null},"label":"Device 1"}`},
+				{Id: "service", Value: `{"device_selection":{"device_id":"d2","service_id":"s1","path":null},"label":"Device 2 / S1"}`},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := controller.ValidateInstanceParameters(descriptions, test.parameters, options)
+			if test.valid && err != nil {
+				t.Error(err)
+			}
+			if !test.valid && err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
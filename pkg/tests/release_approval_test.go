@@ -0,0 +1,203 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"runtime/debug"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/camunda"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestReleaseApproval(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	t.Setenv("RELEASE_PUBLISHER_ROLE", "publisher")
+	t.Setenv("RELEASE_REVIEWER_ROLE", "user")
+
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, config, _, err := apiTestEnv(ctx, wg, true, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	camundaClient := camunda.New(config)
+
+	design, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+		Name:    "design",
+		BpmnXml: resources.NamedDescBpmn,
+		SvgXml:  resources.NamedDescSvg,
+	})
+	if !ok {
+		return
+	}
+	createRelease := func(name string) (model.SmartServiceRelease, bool) {
+		result, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+			DesignId: design.Id,
+			Name:     name,
+		})
+		time.Sleep(time.Second)
+		return result, ok
+	}
+	checkDeployed := func(t *testing.T, id string, expected bool) {
+		t.Helper()
+		deployed, err := camundaClient.IsReleaseDeployed(id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if deployed != expected {
+			t.Error(id, deployed, expected)
+		}
+	}
+	releaseUrl := func(id string) string {
+		return apiUrl + "/releases/" + url.PathEscape(id)
+	}
+
+	first, ok := createRelease("first")
+	if !ok {
+		return
+	}
+
+	t.Run("pending", func(t *testing.T) {
+		if first.LifecycleState != model.ReleaseLifecycleStatePendingApproval {
+			t.Error(first.LifecycleState)
+		}
+		checkDeployed(t, first.Id, false)
+		release, ok := request[model.SmartServiceRelease](t, http.MethodGet, userToken, releaseUrl(first.Id), nil)
+		if ok && release.LifecycleState != model.ReleaseLifecycleStatePendingApproval {
+			t.Error(release.LifecycleState)
+		}
+		testRequestStatus(t, http.MethodGet, secondUserToken, releaseUrl(first.Id), nil, http.StatusForbidden)
+		testRequestStatus(t, http.MethodPost, userToken, releaseUrl(first.Id)+"/instances", model.SmartServiceInstanceInit{
+			SmartServiceInstanceInfo: model.SmartServiceInstanceInfo{Name: "instance"},
+			Parameters:               []model.SmartServiceParameter{},
+		}, http.StatusBadRequest)
+	})
+
+	t.Run("list pending", func(t *testing.T) {
+		releases, ok := request[[]model.SmartServiceReleaseExtended](t, http.MethodGet, secondUserToken, apiUrl+"/releases/pending", nil)
+		if ok && (len(releases) != 1 || releases[0].Id != first.Id) {
+			t.Errorf("%#v", releases)
+		}
+	})
+
+	t.Run("review own release", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPost, userToken, releaseUrl(first.Id)+"/approve", model.SmartServiceReleaseReview{}, http.StatusForbidden)
+	})
+
+	t.Run("reject without comment", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPost, secondUserToken, releaseUrl(first.Id)+"/reject", model.SmartServiceReleaseReview{}, http.StatusBadRequest)
+	})
+
+	t.Run("approve", func(t *testing.T) {
+		release, ok := request[model.SmartServiceRelease](t, http.MethodPost, secondUserToken, releaseUrl(first.Id)+"/approve", model.SmartServiceReleaseReview{Comment: "lgtm"})
+		if !ok {
+			return
+		}
+		if release.LifecycleState != model.ReleaseLifecycleStatePublished || release.Approval == nil || !release.Approval.Approved || release.Approval.ReviewerId != secondUserId || release.Approval.Comment != "lgtm" {
+			t.Errorf("%#v", release)
+		}
+		checkDeployed(t, first.Id, true)
+		testRequestStatus(t, http.MethodPost, secondUserToken, releaseUrl(first.Id)+"/approve", model.SmartServiceReleaseReview{}, http.StatusBadRequest)
+		releases, ok := request[[]model.SmartServiceReleaseExtended](t, http.MethodGet, secondUserToken, apiUrl+"/releases/pending", nil)
+		if ok && len(releases) != 0 {
+			t.Errorf("%#v", releases)
+		}
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		second, ok := createRelease("second")
+		if !ok {
+			return
+		}
+		release, ok := request[model.SmartServiceRelease](t, http.MethodPost, secondUserToken, releaseUrl(second.Id)+"/reject", model.SmartServiceReleaseReview{Comment: "not yet"})
+		if !ok {
+			return
+		}
+		if release.LifecycleState != model.ReleaseLifecycleStateRejected || release.Approval == nil || release.Approval.Approved || release.Approval.Comment != "not yet" {
+			t.Errorf("%#v", release)
+		}
+		checkDeployed(t, second.Id, false)
+		latest, ok := request[[]model.SmartServiceRelease](t, http.MethodGet, userToken, apiUrl+"/releases?latest=true", nil)
+		if ok && (len(latest) != 1 || latest[0].Id != first.Id) {
+			t.Errorf("%#v", latest)
+		}
+	})
+
+	t.Run("publish draft", func(t *testing.T) {
+		draft, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+			DesignId:       design.Id,
+			Name:           "draft",
+			LifecycleState: model.ReleaseLifecycleStateDraft,
+		})
+		if !ok {
+			return
+		}
+		time.Sleep(time.Second)
+		checkDeployed(t, draft.Id, false)
+		testRequestStatus(t, http.MethodPost, userToken, releaseUrl(draft.Id)+"/instances", model.SmartServiceInstanceInit{
+			SmartServiceInstanceInfo: model.SmartServiceInstanceInfo{Name: "draft instance"},
+			Parameters:               []model.SmartServiceParameter{},
+		}, http.StatusBadRequest)
+		release, ok := request[model.SmartServiceRelease](t, http.MethodPut, userToken, releaseUrl(draft.Id)+"/lifecycle-state", model.SmartServiceReleaseLifecycleStateUpdate{
+			LifecycleState: model.ReleaseLifecycleStatePublished,
+		})
+		if ok && release.LifecycleState != model.ReleaseLifecycleStatePendingApproval {
+			t.Error(release.LifecycleState)
+		}
+		checkDeployed(t, draft.Id, false)
+		_, ok = request[model.SmartServiceRelease](t, http.MethodPost, secondUserToken, releaseUrl(draft.Id)+"/approve", model.SmartServiceReleaseReview{})
+		if ok {
+			checkDeployed(t, draft.Id, true)
+		}
+	})
+
+	t.Run("publisher", func(t *testing.T) {
+		adminDesign, ok := request[model.SmartServiceDesign](t, http.MethodPost, adminToken, apiUrl+"/designs", model.SmartServiceDesign{
+			Name:    "admin design",
+			BpmnXml: resources.NamedDescBpmn,
+			SvgXml:  resources.NamedDescSvg,
+		})
+		if !ok {
+			return
+		}
+		release, ok := request[model.SmartServiceRelease](t, http.MethodPost, adminToken, apiUrl+"/releases", model.SmartServiceRelease{
+			DesignId: adminDesign.Id,
+			Name:     "admin",
+		})
+		if ok && release.LifecycleState != model.ReleaseLifecycleStatePublished {
+			t.Error(release.LifecycleState)
+		}
+	})
+}
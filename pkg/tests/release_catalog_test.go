@@ -0,0 +1,177 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestReleaseCatalog(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, err := apiTestEnv(ctx, wg, false, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	createRelease := func(bpmn string, release model.SmartServiceRelease) (model.SmartServiceRelease, bool) {
+		design, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+			BpmnXml: bpmn,
+			SvgXml:  resources.NamedDescSvg,
+		})
+		if !ok {
+			return model.SmartServiceRelease{}, false
+		}
+		release.DesignId = design.Id
+		return request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", release)
+	}
+
+	annotatedBpmn := strings.Replace(resources.NamedDescBpmn, `senergy:description="test description"`, `senergy:description="test description" senergy:category="energy" senergy:tags="heating, solar,heating" senergy:icon="https://example.com/heating.svg"`, 1)
+
+	fromBpmn, ok := createRelease(annotatedBpmn, model.SmartServiceRelease{Name: "from bpmn"})
+	if !ok {
+		return
+	}
+	explicit, ok := createRelease(annotatedBpmn, model.SmartServiceRelease{Name: "explicit", Category: "mobility", Tags: []string{" solar "}, Icon: "https://example.com/car.svg"})
+	if !ok {
+		return
+	}
+	plain, ok := createRelease(resources.NamedDescBpmn, model.SmartServiceRelease{Name: "plain"})
+	if !ok {
+		return
+	}
+	time.Sleep(time.Second)
+
+	t.Run("defaults from bpmn", func(t *testing.T) {
+		release, ok := request[model.SmartServiceRelease](t, http.MethodGet, userToken, apiUrl+"/releases/"+fromBpmn.Id, nil)
+		if !ok {
+			return
+		}
+		if release.Category != "energy" || !reflect.DeepEqual(release.Tags, []string{"heating", "solar"}) || release.Icon != "https://example.com/heating.svg" {
+			t.Errorf("%#v", release)
+		}
+	})
+
+	t.Run("explicit values", func(t *testing.T) {
+		release, ok := request[model.SmartServiceRelease](t, http.MethodGet, userToken, apiUrl+"/releases/"+explicit.Id, nil)
+		if !ok {
+			return
+		}
+		if release.Category != "mobility" || !reflect.DeepEqual(release.Tags, []string{"solar"}) || release.Icon != "https://example.com/car.svg" {
+			t.Errorf("%#v", release)
+		}
+	})
+
+	t.Run("no catalog info", func(t *testing.T) {
+		release, ok := request[model.SmartServiceRelease](t, http.MethodGet, userToken, apiUrl+"/releases/"+plain.Id, nil)
+		if !ok {
+			return
+		}
+		if release.Category != "" || len(release.Tags) != 0 || release.Icon != "" {
+			t.Errorf("%#v", release)
+		}
+	})
+
+	check := func(query string, expectedIds ...string) {
+		t.Run(query, func(t *testing.T) {
+			releases, ok := request[[]model.SmartServiceRelease](t, http.MethodGet, userToken, apiUrl+"/releases?"+query, nil)
+			if !ok {
+				return
+			}
+			actualIds := []string{}
+			for _, release := range releases {
+				actualIds = append(actualIds, release.Id)
+			}
+			sort.Strings(actualIds)
+			sort.Strings(expectedIds)
+			if !reflect.DeepEqual(actualIds, expectedIds) {
+				t.Error(actualIds, expectedIds)
+			}
+		})
+	}
+
+	check("category=energy", fromBpmn.Id)
+	check("category=mobility", explicit.Id)
+	check("category=foo")
+	check("tags=solar", fromBpmn.Id, explicit.Id)
+	check("tags=solar,heating", fromBpmn.Id)
+	check("tags=solar&category=mobility", explicit.Id)
+
+	t.Run("extended", func(t *testing.T) {
+		releases, ok := request[[]model.SmartServiceReleaseExtended](t, http.MethodGet, userToken, apiUrl+"/extended-releases?tags=heating", nil)
+		if ok && (len(releases) != 1 || releases[0].Id != fromBpmn.Id) {
+			t.Errorf("%#v", releases)
+		}
+	})
+
+	t.Run("facets", func(t *testing.T) {
+		facets, ok := request[model.ReleaseFacets](t, http.MethodGet, userToken, apiUrl+"/release-facets", nil)
+		if !ok {
+			return
+		}
+		expected := model.ReleaseFacets{
+			Categories: []model.ReleaseFacetCount{{Value: "energy", Count: 1}, {Value: "mobility", Count: 1}},
+			Tags:       []model.ReleaseFacetCount{{Value: "solar", Count: 2}, {Value: "heating", Count: 1}},
+		}
+		if !reflect.DeepEqual(facets, expected) {
+			t.Errorf("\n%#v\n%#v", facets, expected)
+		}
+	})
+
+	t.Run("filtered facets", func(t *testing.T) {
+		facets, ok := request[model.ReleaseFacets](t, http.MethodGet, userToken, apiUrl+"/release-facets?category=mobility", nil)
+		if !ok {
+			return
+		}
+		expected := model.ReleaseFacets{
+			Categories: []model.ReleaseFacetCount{{Value: "mobility", Count: 1}},
+			Tags:       []model.ReleaseFacetCount{{Value: "solar", Count: 1}},
+		}
+		if !reflect.DeepEqual(facets, expected) {
+			t.Errorf("\n%#v\n%#v", facets, expected)
+		}
+	})
+
+	t.Run("facets of other user", func(t *testing.T) {
+		facets, ok := request[model.ReleaseFacets](t, http.MethodGet, secondUserToken, apiUrl+"/release-facets", nil)
+		if ok && (len(facets.Categories) != 0 || len(facets.Tags) != 0) {
+			t.Errorf("%#v", facets)
+		}
+	})
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestReleaseCreation(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, err := apiTestEnv(ctx, wg, false, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	waitForCreation := func(t *testing.T, releaseId string) (result model.SmartServiceReleaseCreation) {
+		t.Helper()
+		for i := 0; i < 20; i++ {
+			var ok bool
+			result, ok = request[model.SmartServiceReleaseCreation](t, http.MethodGet, userToken, apiUrl+"/releases/"+url.PathEscape(releaseId)+"/status", nil)
+			if !ok || result.Status == model.ReleaseCreationStatusReady || result.Status == model.ReleaseCreationStatusFailed {
+				return result
+			}
+			time.Sleep(500 * time.Millisecond)
+		}
+		t.Error("release creation did not finish", result.Status)
+		return result
+	}
+
+	design, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+		Name:    "design",
+		BpmnXml: resources.ParamsBpmn,
+		SvgXml:  resources.ParamsSvg,
+	})
+	if !ok {
+		return
+	}
+	invalidDesign, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+		Name:    "invalid",
+		BpmnXml: "<bpmn:definitions",
+	})
+	if !ok {
+		return
+	}
+
+	t.Run("invalid request", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{Name: "missing design"}, http.StatusBadRequest)
+	})
+
+	release := model.SmartServiceRelease{}
+	t.Run("create", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPost, userToken, apiUrl+"/releases?async=true", model.SmartServiceRelease{
+			DesignId: design.Id,
+			Name:     "status check",
+		}, http.StatusAccepted)
+		release, ok = request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases?async=true", model.SmartServiceRelease{
+			DesignId: design.Id,
+			Name:     "async",
+		})
+		if !ok {
+			return
+		}
+		creation := waitForCreation(t, release.Id)
+		if creation.Status != model.ReleaseCreationStatusReady || creation.DesignId != design.Id || creation.UserId != userId || creation.Name != "async" {
+			t.Errorf("%#v", creation)
+		}
+		testRequestStatus(t, http.MethodGet, userToken, apiUrl+"/releases/"+url.PathEscape(release.Id), nil, http.StatusOK)
+	})
+
+	failed := model.SmartServiceRelease{}
+	t.Run("create invalid", func(t *testing.T) {
+		failed, ok = request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases?async=true", model.SmartServiceRelease{
+			DesignId: invalidDesign.Id,
+			Name:     "invalid",
+		})
+		if !ok {
+			return
+		}
+		creation := waitForCreation(t, failed.Id)
+		if creation.Status != model.ReleaseCreationStatusFailed || creation.Error == "" {
+			t.Errorf("%#v", creation)
+		}
+		testRequestStatus(t, http.MethodGet, userToken, apiUrl+"/releases/"+url.PathEscape(failed.Id), nil, http.StatusNotFound)
+	})
+
+	t.Run("create invalid synchronously", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+			DesignId: invalidDesign.Id,
+			Name:     "invalid",
+		}, http.StatusBadRequest)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		list, ok := request[[]model.SmartServiceReleaseCreation](t, http.MethodGet, userToken, apiUrl+"/release-creations?status=failed", nil)
+		if ok && (len(list) != 2 || !slices.ContainsFunc(list, func(creation model.SmartServiceReleaseCreation) bool { return creation.ReleaseId == failed.Id })) {
+			t.Errorf("%#v", list)
+		}
+		list, ok = request[[]model.SmartServiceReleaseCreation](t, http.MethodGet, userToken, apiUrl+"/release-creations", nil)
+		if ok && (len(list) != 4 || !slices.ContainsFunc(list, func(creation model.SmartServiceReleaseCreation) bool { return creation.ReleaseId == release.Id })) {
+			t.Errorf("%#v", list)
+		}
+		list, ok = request[[]model.SmartServiceReleaseCreation](t, http.MethodGet, secondUserToken, apiUrl+"/release-creations", nil)
+		if ok && len(list) != 0 {
+			t.Errorf("%#v", list)
+		}
+		testRequestStatus(t, http.MethodGet, userToken, apiUrl+"/release-creations?status=unknown", nil, http.StatusBadRequest)
+	})
+
+	t.Run("status access", func(t *testing.T) {
+		testRequestStatus(t, http.MethodGet, secondUserToken, apiUrl+"/releases/"+url.PathEscape(release.Id)+"/status", nil, http.StatusForbidden)
+	})
+}
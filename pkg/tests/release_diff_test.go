@@ -0,0 +1,248 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/controller"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestReleaseInfoDiff(t *testing.T) {
+	characteristicA := "a"
+	characteristicB := "b"
+	functionId := "f"
+	from := model.SmartServiceReleaseInfo{
+		ParameterDescriptions: []model.ParameterDescription{
+			{Id: "unchanged", Label: "Unchanged", Type: "string", Options: map[string]interface{}{}},
+			{Id: "label", Label: "Label", Type: "string"},
+			{Id: "type", Label: "Type", Type: "string"},
+			{Id: "optional", Label: "Optional", Type: "string", Optional: true},
+			{Id: "characteristic", Label: "Characteristic", Type: "float", CharacteristicId: &characteristicA},
+			{Id: "device", Label: "Device", Type: "string", IotDescription: &model.IotDescription{
+				Criteria: []model.Criteria{{FunctionId: &functionId}},
+			}},
+			{Id: "removed", Label: "Removed", Type: "string"},
+		},
+		MaintenanceProcedures: []model.MaintenanceProcedure{
+			{BpmnId: "StartEvent_1", PublicEventId: "unchanged"},
+			{BpmnId: "StartEvent_2", PublicEventId: "changed", ParameterDescriptions: []model.ParameterDescription{
+				{Id: "p", Type: "string"},
+			}},
+			{BpmnId: "StartEvent_3", PublicEventId: "removed"},
+		},
+		ModuleInfo: model.ReleaseModuleInfo{Analytics: []model.AnalyticsReleaseModuleInfo{
+			{FlowId: "unchanged", Name: "unchanged"},
+			{FlowId: "changed", Name: "old", Desc: "desc"},
+		}},
+	}
+	to := model.SmartServiceReleaseInfo{
+		ParameterDescriptions: []model.ParameterDescription{
+			{Id: "unchanged", Label: "Unchanged", Type: "string"},
+			{Id: "label", Label: "Label 2", Type: "string"},
+			{Id: "type", Label: "Type", Type: "float"},
+			{Id: "optional", Label: "Optional", Type: "string"},
+			{Id: "characteristic", Label: "Characteristic", Type: "float", CharacteristicId: &characteristicB},
+			{Id: "device", Label: "Device", Type: "string", IotDescription: &model.IotDescription{
+				Criteria: []model.Criteria{{FunctionId: &functionId}},
+			}, Multiple: true},
+			{Id: "added", Label: "Added", Type: "string"},
+			{Id: "added_optional", Label: "Added Optional", Type: "string", Optional: true},
+		},
+		MaintenanceProcedures: []model.MaintenanceProcedure{
+			{BpmnId: "StartEvent_1", PublicEventId: "unchanged"},
+			{BpmnId: "StartEvent_2", PublicEventId: "changed", ParameterDescriptions: []model.ParameterDescription{
+				{Id: "p", Type: "string"},
+				{Id: "q", Type: "string"},
+			}},
+			{BpmnId: "StartEvent_4", PublicEventId: "added"},
+		},
+		ModuleInfo: model.ReleaseModuleInfo{Analytics: []model.AnalyticsReleaseModuleInfo{
+			{FlowId: "unchanged", Name: "unchanged"},
+			{FlowId: "changed", Name: "new", Desc: "desc"},
+			{FlowId: "added", Name: "added"},
+		}},
+	}
+
+	result := controller.DiffReleaseInfo(from, to)
+
+	expectedParameters := model.ParameterDiffSection{
+		Added: []model.ParameterDiffEntry{
+			{Id: "added", Label: "Added", Type: "string"},
+			{Id: "added_optional", Label: "Added Optional", Type: "string"},
+		},
+		Removed: []model.ParameterDiffEntry{
+			{Id: "removed", Label: "Removed", Type: "string"},
+		},
+		Changed: []model.ParameterDiffEntry{
+			{Id: "characteristic", Label: "Characteristic", Type: "float", Changes: []string{"characteristic"}},
+			{Id: "device", Label: "Device", Type: "string", Changes: []string{"multiple"}},
+			{Id: "label", Label: "Label 2", Type: "string", Changes: []string{"label"}},
+			{Id: "optional", Label: "Optional", Type: "string", Changes: []string{"optional"}},
+			{Id: "type", Label: "Type", Type: "float", Changes: []string{"type"}},
+		},
+	}
+	if !reflect.DeepEqual(result.Parameters, expectedParameters) {
+		t.Errorf("%#v", result.Parameters)
+	}
+
+	expectedReenter := []string{"added", "characteristic", "device", "optional", "type"}
+	if !reflect.DeepEqual(result.ReenterParameterIds, expectedReenter) {
+		t.Errorf("%#v", result.ReenterParameterIds)
+	}
+
+	expectedProcedures := model.MaintenanceProcedureDiffSection{
+		Added:   []model.MaintenanceProcedureDiffEntry{{PublicEventId: "added", BpmnId: "StartEvent_4"}},
+		Removed: []model.MaintenanceProcedureDiffEntry{{PublicEventId: "removed", BpmnId: "StartEvent_3"}},
+		Changed: []model.MaintenanceProcedureDiffEntry{{
+			PublicEventId: "changed",
+			BpmnId:        "StartEvent_2",
+			Changes:       []string{"parameters"},
+			Parameters: &model.ParameterDiffSection{
+				Added:   []model.ParameterDiffEntry{{Id: "q", Type: "string"}},
+				Removed: []model.ParameterDiffEntry{},
+				Changed: []model.ParameterDiffEntry{},
+			},
+		}},
+	}
+	if !reflect.DeepEqual(result.MaintenanceProcedures, expectedProcedures) {
+		t.Errorf("%#v", result.MaintenanceProcedures)
+	}
+
+	expectedAnalytics := model.AnalyticsDiffSection{
+		Added:   []model.AnalyticsDiffEntry{{FlowId: "added", Name: "added"}},
+		Removed: []model.AnalyticsDiffEntry{},
+		Changed: []model.AnalyticsDiffEntry{{FlowId: "changed", Name: "new", Changes: []string{"name"}}},
+	}
+	if !reflect.DeepEqual(result.Analytics, expectedAnalytics) {
+		t.Errorf("%#v", result.Analytics)
+	}
+
+	result = controller.DiffReleaseInfo(from, from)
+	if len(result.Parameters.Added)+len(result.Parameters.Removed)+len(result.Parameters.Changed) != 0 ||
+		len(result.MaintenanceProcedures.Changed) != 0 ||
+		len(result.Analytics.Changed) != 0 ||
+		len(result.ReenterParameterIds) != 0 {
+		t.Errorf("%#v", result)
+	}
+}
+
+func TestReleaseDiff(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	t.Setenv("DELETE_UNUSED_OLD_VERSION_RELEASES", "false")
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, err := apiTestEnv(ctx, wg, false, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	design, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+		Name:    "design",
+		BpmnXml: resources.ParamsBpmn,
+		SvgXml:  resources.ParamsSvg,
+	})
+	if !ok {
+		return
+	}
+	first, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+		DesignId: design.Id,
+		Name:     "first",
+	})
+	if !ok {
+		return
+	}
+
+	design.BpmnXml = strings.Replace(resources.ParamsBpmn, `label="Device Selection"`, `label="Device"`, 1)
+	design.BpmnXml = strings.Replace(design.BpmnXml, `{&#34;device_class_id&#34;:&#34;foo&#34;}`, `{&#34;device_class_id&#34;:&#34;bar&#34;}`, 1)
+	design, ok = request[model.SmartServiceDesign](t, http.MethodPut, userToken, apiUrl+"/designs/"+url.PathEscape(design.Id), design)
+	if !ok {
+		return
+	}
+	second, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+		DesignId: design.Id,
+		Name:     "second",
+	})
+	if !ok {
+		return
+	}
+	time.Sleep(time.Second)
+
+	t.Run("diff", func(t *testing.T) {
+		result, ok := request[model.SmartServiceReleaseDiff](t, http.MethodGet, userToken, apiUrl+"/releases/"+url.PathEscape(first.Id)+"/diff/"+url.PathEscape(second.Id), nil)
+		if !ok {
+			return
+		}
+		if result.ReleaseId != first.Id || result.OtherReleaseId != second.Id {
+			t.Errorf("%#v", result)
+		}
+		changed := map[string][]string{}
+		for _, entry := range result.Parameters.Changed {
+			changed[entry.Id] = entry.Changes
+		}
+		if !reflect.DeepEqual(changed, map[string][]string{"device": {"label"}, "group": {"iot_criteria"}}) {
+			t.Errorf("%#v", result.Parameters)
+		}
+		if !reflect.DeepEqual(result.ReenterParameterIds, []string{"group"}) {
+			t.Errorf("%#v", result.ReenterParameterIds)
+		}
+	})
+
+	t.Run("different designs", func(t *testing.T) {
+		otherDesign, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+			Name:    "other",
+			BpmnXml: resources.ParamsBpmn,
+			SvgXml:  resources.ParamsSvg,
+		})
+		if !ok {
+			return
+		}
+		other, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+			DesignId: otherDesign.Id,
+			Name:     "other",
+		})
+		if !ok {
+			return
+		}
+		time.Sleep(time.Second)
+		testRequestStatus(t, http.MethodGet, userToken, apiUrl+"/releases/"+url.PathEscape(first.Id)+"/diff/"+url.PathEscape(other.Id), nil, http.StatusBadRequest)
+	})
+
+	t.Run("missing rights", func(t *testing.T) {
+		testRequestStatus(t, http.MethodGet, secondUserToken, apiUrl+"/releases/"+url.PathEscape(first.Id)+"/diff/"+url.PathEscape(second.Id), nil, http.StatusForbidden)
+	})
+}
@@ -0,0 +1,203 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestReleaseLifecycle(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, err := apiTestEnv(ctx, wg, true, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	releaseIds := func(token string) (result []string) {
+		releases, _ := request[[]model.SmartServiceRelease](t, http.MethodGet, token, apiUrl+"/releases", nil)
+		for _, release := range releases {
+			result = append(result, release.Id)
+		}
+		return result
+	}
+	setState := func(t *testing.T, releaseId string, state string, expectedStatus int) {
+		testRequestStatus(t, http.MethodPut, userToken, apiUrl+"/releases/"+url.PathEscape(releaseId)+"/lifecycle-state", model.SmartServiceReleaseLifecycleStateUpdate{
+			LifecycleState: state,
+			Message:        "test message",
+		}, expectedStatus)
+	}
+
+	design, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+		Name:    "design",
+		BpmnXml: resources.NamedDescBpmn,
+		SvgXml:  resources.NamedDescSvg,
+	})
+	if !ok {
+		return
+	}
+	published, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+		DesignId: design.Id,
+		Name:     "published",
+	})
+	if !ok {
+		return
+	}
+	if published.LifecycleState != model.ReleaseLifecycleStatePublished {
+		t.Error(published.LifecycleState)
+	}
+	time.Sleep(time.Second)
+
+	t.Run("new releases may not be retired", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+			DesignId:       design.Id,
+			Name:           "retired",
+			LifecycleState: model.ReleaseLifecycleStateRetired,
+		}, http.StatusBadRequest)
+	})
+
+	draft, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+		DesignId:       design.Id,
+		Name:           "draft",
+		LifecycleState: model.ReleaseLifecycleStateDraft,
+	})
+	if !ok {
+		return
+	}
+	time.Sleep(time.Second)
+
+	t.Run("drafts are only visible to their creator", func(t *testing.T) {
+		if ids := releaseIds(userToken); !slices.Contains(ids, draft.Id) || !slices.Contains(ids, published.Id) {
+			t.Error(ids)
+		}
+		if ids := releaseIds(adminToken); slices.Contains(ids, draft.Id) || !slices.Contains(ids, published.Id) {
+			t.Error(ids)
+		}
+		testRequestStatus(t, http.MethodGet, adminToken, apiUrl+"/releases/"+url.PathEscape(draft.Id), nil, http.StatusForbidden)
+		result, ok := request[model.SmartServiceRelease](t, http.MethodGet, userToken, apiUrl+"/releases/"+url.PathEscape(published.Id), nil)
+		if ok && result.NewReleaseId != "" {
+			t.Error("draft should not replace published release", result.NewReleaseId)
+		}
+	})
+
+	t.Run("drafts may not be used for instances", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(draft.Id)+"/instances", model.SmartServiceInstanceInit{
+			SmartServiceInstanceInfo: model.SmartServiceInstanceInfo{Name: "draft instance"},
+			Parameters:               []model.SmartServiceParameter{},
+		}, http.StatusBadRequest)
+	})
+
+	instance, ok := request[model.SmartServiceInstance](t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(published.Id)+"/instances", model.SmartServiceInstanceInit{
+		SmartServiceInstanceInfo: model.SmartServiceInstanceInfo{Name: "instance"},
+		Parameters:               []model.SmartServiceParameter{},
+	})
+	if !ok {
+		return
+	}
+	time.Sleep(time.Second)
+
+	t.Run("invalid transitions", func(t *testing.T) {
+		setState(t, published.Id, model.ReleaseLifecycleStateDraft, http.StatusBadRequest)
+		setState(t, published.Id, "unknown", http.StatusBadRequest)
+	})
+
+	t.Run("only admins of the release may change the state", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPut, secondUserToken, apiUrl+"/releases/"+url.PathEscape(published.Id)+"/lifecycle-state", model.SmartServiceReleaseLifecycleStateUpdate{
+			LifecycleState: model.ReleaseLifecycleStateRetired,
+		}, http.StatusForbidden)
+	})
+
+	t.Run("publish draft", func(t *testing.T) {
+		setState(t, draft.Id, model.ReleaseLifecycleStatePublished, http.StatusOK)
+		if ids := releaseIds(adminToken); !slices.Contains(ids, draft.Id) {
+			t.Error(ids)
+		}
+		result, ok := request[model.SmartServiceRelease](t, http.MethodGet, userToken, apiUrl+"/releases/"+url.PathEscape(published.Id), nil)
+		if ok && result.NewReleaseId != draft.Id {
+			t.Error(result.NewReleaseId)
+		}
+	})
+
+	t.Run("deprecate", func(t *testing.T) {
+		setState(t, published.Id, model.ReleaseLifecycleStateDeprecated, http.StatusOK)
+		releases, ok := request[[]model.SmartServiceRelease](t, http.MethodGet, userToken, apiUrl+"/releases", nil)
+		if ok && !slices.ContainsFunc(releases, func(release model.SmartServiceRelease) bool {
+			return release.Id == published.Id && release.LifecycleState == model.ReleaseLifecycleStateDeprecated
+		}) {
+			t.Errorf("%#v", releases)
+		}
+		testRequestStatus(t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(published.Id)+"/instances", model.SmartServiceInstanceInit{
+			SmartServiceInstanceInfo: model.SmartServiceInstanceInfo{Name: "instance 2"},
+			Parameters:               []model.SmartServiceParameter{},
+		}, http.StatusOK)
+	})
+
+	t.Run("retire", func(t *testing.T) {
+		setState(t, published.Id, model.ReleaseLifecycleStateRetired, http.StatusOK)
+		testRequestStatus(t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(published.Id)+"/instances", model.SmartServiceInstanceInit{
+			SmartServiceInstanceInfo: model.SmartServiceInstanceInfo{Name: "instance 3"},
+			Parameters:               []model.SmartServiceParameter{},
+		}, http.StatusBadRequest)
+		testRequestStatus(t, http.MethodPut, userToken, apiUrl+"/instances/"+url.PathEscape(instance.Id)+"/parameters", []model.SmartServiceParameter{}, http.StatusBadRequest)
+		testRequestStatus(t, http.MethodGet, userToken, apiUrl+"/instances/"+url.PathEscape(instance.Id), nil, http.StatusOK)
+	})
+
+	t.Run("redeploy with inaccessible release", func(t *testing.T) {
+		foreignDesign, ok := request[model.SmartServiceDesign](t, http.MethodPost, secondUserToken, apiUrl+"/designs", model.SmartServiceDesign{
+			Name:    "foreign design",
+			BpmnXml: resources.NamedDescBpmn,
+			SvgXml:  resources.NamedDescSvg,
+		})
+		if !ok {
+			return
+		}
+		foreign, ok := request[model.SmartServiceRelease](t, http.MethodPost, secondUserToken, apiUrl+"/releases", model.SmartServiceRelease{
+			DesignId: foreignDesign.Id,
+			Name:     "foreign",
+		})
+		if !ok {
+			return
+		}
+		time.Sleep(time.Second)
+		testRequestStatus(t, http.MethodPut, userToken, apiUrl+"/instances/"+url.PathEscape(instance.Id)+"/parameters?release_id="+url.QueryEscape(foreign.Id), []model.SmartServiceParameter{{Id: "unknown"}}, http.StatusForbidden)
+	})
+
+	t.Run("redeploy with newer release", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPut, userToken, apiUrl+"/instances/"+url.PathEscape(instance.Id)+"/parameters?release_id="+url.QueryEscape(draft.Id), []model.SmartServiceParameter{}, http.StatusOK)
+	})
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	permmodel "github.com/SENERGY-Platform/permissions-v2/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestReleasePermissions(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	t.Setenv("DELETE_UNUSED_OLD_VERSION_RELEASES", "false")
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, err := apiTestEnv(ctx, wg, false, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	design, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+		Name:    "design",
+		BpmnXml: resources.NamedDescBpmn,
+		SvgXml:  resources.NamedDescSvg,
+	})
+	if !ok {
+		return
+	}
+	createRelease := func(name string) (model.SmartServiceRelease, bool) {
+		result, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+			DesignId: design.Id,
+			Name:     name,
+		})
+		time.Sleep(time.Second)
+		return result, ok
+	}
+	first, ok := createRelease("first")
+	if !ok {
+		return
+	}
+	second, ok := createRelease("second")
+	if !ok {
+		return
+	}
+	permissionsUrl := func(id string) string {
+		return apiUrl + "/releases/" + url.PathEscape(id) + "/permissions"
+	}
+
+	shared := model.ReleasePermissions{
+		UserPermissions: map[string]permmodel.PermissionsMap{
+			userId:       {Read: true, Write: true, Execute: true, Administrate: true},
+			secondUserId: {Read: true, Execute: true},
+		},
+	}
+
+	t.Run("get", func(t *testing.T) {
+		result, ok := request[model.ReleasePermissions](t, http.MethodGet, userToken, permissionsUrl(second.Id), nil)
+		if ok && !result.UserPermissions[userId].Administrate {
+			t.Errorf("%#v", result)
+		}
+		testRequestStatus(t, http.MethodGet, secondUserToken, permissionsUrl(second.Id), nil, http.StatusForbidden)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPut, userToken, permissionsUrl(second.Id), model.ReleasePermissions{
+			UserPermissions: map[string]permmodel.PermissionsMap{userId: {Read: true}},
+		}, http.StatusBadRequest)
+		testRequestStatus(t, http.MethodPut, userToken, permissionsUrl(second.Id), model.ReleasePermissions{
+			UserPermissions: map[string]permmodel.PermissionsMap{userId: {Read: true, Administrate: true}, secondUserId: {Execute: true}},
+		}, http.StatusBadRequest)
+		testRequestStatus(t, http.MethodPut, secondUserToken, permissionsUrl(second.Id), shared, http.StatusForbidden)
+	})
+
+	t.Run("set", func(t *testing.T) {
+		result, ok := request[model.ReleasePermissionsUpdateResult](t, http.MethodPut, userToken, permissionsUrl(second.Id), shared)
+		if !ok {
+			return
+		}
+		if !slices.Equal(result.UpdatedReleaseIds, []string{second.Id}) || !result.Permissions.UserPermissions[secondUserId].Execute {
+			t.Errorf("%#v", result)
+		}
+		time.Sleep(time.Second)
+		testRequestStatus(t, http.MethodGet, secondUserToken, apiUrl+"/releases/"+url.PathEscape(second.Id), nil, http.StatusOK)
+		testRequestStatus(t, http.MethodGet, secondUserToken, apiUrl+"/releases/"+url.PathEscape(first.Id), nil, http.StatusForbidden)
+	})
+
+	t.Run("propagate", func(t *testing.T) {
+		result, ok := request[model.ReleasePermissionsUpdateResult](t, http.MethodPut, userToken, permissionsUrl(second.Id)+"?propagate=true", shared)
+		if !ok {
+			return
+		}
+		sort.Strings(result.UpdatedReleaseIds)
+		expected := []string{first.Id, second.Id}
+		sort.Strings(expected)
+		if !slices.Equal(result.UpdatedReleaseIds, expected) || len(result.SkippedReleaseIds) != 0 || len(result.FailedReleaseIds) != 0 {
+			t.Errorf("%#v", result)
+		}
+		time.Sleep(time.Second)
+		testRequestStatus(t, http.MethodGet, secondUserToken, apiUrl+"/releases/"+url.PathEscape(first.Id), nil, http.StatusOK)
+	})
+
+	t.Run("propagate skips versions without administrate rights", func(t *testing.T) {
+		_, ok := request[model.ReleasePermissionsUpdateResult](t, http.MethodPut, userToken, permissionsUrl(first.Id), model.ReleasePermissions{
+			UserPermissions: map[string]permmodel.PermissionsMap{
+				userId:       {Read: true},
+				secondUserId: {Read: true, Write: true, Execute: true, Administrate: true},
+			},
+		})
+		if !ok {
+			return
+		}
+		time.Sleep(time.Second)
+		result, ok := request[model.ReleasePermissionsUpdateResult](t, http.MethodPut, userToken, permissionsUrl(second.Id)+"?propagate=true", shared)
+		if ok && (!slices.Equal(result.UpdatedReleaseIds, []string{second.Id}) || !slices.Equal(result.SkippedReleaseIds, []string{first.Id})) {
+			t.Errorf("%#v", result)
+		}
+	})
+}
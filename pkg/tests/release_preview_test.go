@@ -0,0 +1,149 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"runtime/debug"
+	"sync"
+	"testing"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestReleasePreview(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, err := apiTestEnv(ctx, wg, false, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	design := model.SmartServiceDesign{}
+	t.Run("create design", func(t *testing.T) {
+		resp, err := post(userToken, apiUrl+"/designs", model.SmartServiceDesign{
+			BpmnXml: resources.ParamsBpmn,
+			SvgXml:  resources.ParamsSvg,
+			Name:    "test name",
+		})
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if resp.StatusCode != http.StatusOK {
+			temp, _ := io.ReadAll(resp.Body)
+			t.Error(resp.StatusCode, string(temp))
+			return
+		}
+		err = json.NewDecoder(resp.Body).Decode(&design)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+	})
+
+	t.Run("preview bpmn with parameters", func(t *testing.T) {
+		preview, ok := testPreviewRelease(t, userToken, apiUrl+"/release-previews?with_parameters=true", model.SmartServiceReleasePreviewRequest{BpmnXml: resources.ParamsBpmn}, http.StatusOK)
+		if !ok {
+			return
+		}
+		if len(preview.ParsedInfo.ParameterDescriptions) != 13 {
+			t.Error(len(preview.ParsedInfo.ParameterDescriptions))
+		}
+		if !reflect.DeepEqual(resources.ExpectedParams1Obj, preview.Parameters) {
+			temp1, _ := json.Marshal(preview.Parameters)
+			temp2, _ := json.Marshal(resources.ExpectedParams1Obj)
+			t.Error("\n", string(temp1), "\n", string(temp2))
+		}
+	})
+
+	t.Run("preview design", func(t *testing.T) {
+		preview, ok := testPreviewRelease(t, userToken, apiUrl+"/release-previews", model.SmartServiceReleasePreviewRequest{DesignId: design.Id}, http.StatusOK)
+		if !ok {
+			return
+		}
+		if len(preview.ParsedInfo.ParameterDescriptions) != 13 {
+			t.Error(len(preview.ParsedInfo.ParameterDescriptions))
+		}
+		if preview.Parameters != nil {
+			t.Error(preview.Parameters)
+		}
+	})
+
+	t.Run("preview design of other user", func(t *testing.T) {
+		testPreviewRelease(t, secondUserToken, apiUrl+"/release-previews", model.SmartServiceReleasePreviewRequest{DesignId: design.Id}, http.StatusForbidden)
+	})
+
+	t.Run("preview invalid bpmn", func(t *testing.T) {
+		testPreviewRelease(t, userToken, apiUrl+"/release-previews", model.SmartServiceReleasePreviewRequest{BpmnXml: "<bpmn:definitions"}, http.StatusBadRequest)
+	})
+
+	t.Run("nothing released", func(t *testing.T) {
+		resp, err := get(userToken, apiUrl+"/releases")
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		releases := []model.SmartServiceRelease{}
+		err = json.NewDecoder(resp.Body).Decode(&releases)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if len(releases) != 0 {
+			t.Error(releases)
+		}
+	})
+}
+
+func testPreviewRelease(t *testing.T, token string, endpoint string, request model.SmartServiceReleasePreviewRequest, expectedStatus int) (result model.SmartServiceReleasePreview, ok bool) {
+	resp, err := post(token, endpoint, request)
+	if err != nil {
+		t.Error(err)
+		return result, false
+	}
+	if resp.StatusCode != expectedStatus {
+		temp, _ := io.ReadAll(resp.Body)
+		t.Error(resp.StatusCode, string(temp))
+		return result, false
+	}
+	if expectedStatus != http.StatusOK {
+		return result, false
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		t.Error(err)
+		return result, false
+	}
+	return result, true
+}
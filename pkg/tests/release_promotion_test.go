@@ -0,0 +1,137 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"runtime/debug"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestReleasePromotion(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, err := apiTestEnv(ctx, wg, true, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	design, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+		Name:    "design",
+		BpmnXml: resources.NamedDescBpmn,
+		SvgXml:  resources.NamedDescSvg,
+	})
+	if !ok {
+		return
+	}
+	createRelease := func(name string) (model.SmartServiceRelease, bool) {
+		result, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+			DesignId: design.Id,
+			Name:     name,
+		})
+		time.Sleep(time.Second)
+		return result, ok
+	}
+	createInstance := func(releaseId string) (model.SmartServiceInstance, bool) {
+		result, ok := request[model.SmartServiceInstance](t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(releaseId)+"/instances", model.SmartServiceInstanceInit{
+			SmartServiceInstanceInfo: model.SmartServiceInstanceInfo{Name: "instance"},
+			Parameters:               []model.SmartServiceParameter{},
+		})
+		time.Sleep(time.Second)
+		return result, ok
+	}
+	checkNewReleaseId := func(t *testing.T, endpoint string, expected string) {
+		t.Helper()
+		result, ok := request[struct {
+			NewReleaseId string `json:"new_release_id"`
+		}](t, http.MethodGet, userToken, apiUrl+endpoint, nil)
+		if ok && result.NewReleaseId != expected {
+			t.Error(endpoint, result.NewReleaseId, expected)
+		}
+	}
+
+	first, ok := createRelease("first")
+	if !ok {
+		return
+	}
+	firstInstance, ok := createInstance(first.Id)
+	if !ok {
+		return
+	}
+	second, ok := createRelease("second")
+	if !ok {
+		return
+	}
+	secondInstance, ok := createInstance(second.Id)
+	if !ok {
+		return
+	}
+
+	t.Run("missing rights", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPost, secondUserToken, apiUrl+"/releases/"+url.PathEscape(first.Id)+"/promote", nil, http.StatusForbidden)
+	})
+
+	t.Run("promote", func(t *testing.T) {
+		result, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(first.Id)+"/promote", nil)
+		if ok && result.NewReleaseId != "" {
+			t.Error(result.NewReleaseId)
+		}
+		checkNewReleaseId(t, "/releases/"+url.PathEscape(first.Id), "")
+		checkNewReleaseId(t, "/releases/"+url.PathEscape(second.Id), first.Id)
+		checkNewReleaseId(t, "/instances/"+url.PathEscape(firstInstance.Id), "")
+		checkNewReleaseId(t, "/instances/"+url.PathEscape(secondInstance.Id), first.Id)
+	})
+
+	t.Run("latest", func(t *testing.T) {
+		releases, ok := request[[]model.SmartServiceRelease](t, http.MethodGet, userToken, apiUrl+"/releases?latest=true", nil)
+		if ok && (len(releases) != 1 || releases[0].Id != first.Id) {
+			t.Errorf("%#v", releases)
+		}
+	})
+
+	t.Run("promote latest", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(first.Id)+"/promote", nil, http.StatusOK)
+	})
+
+	t.Run("new release replaces all", func(t *testing.T) {
+		third, ok := createRelease("third")
+		if !ok {
+			return
+		}
+		checkNewReleaseId(t, "/releases/"+url.PathEscape(first.Id), third.Id)
+		checkNewReleaseId(t, "/releases/"+url.PathEscape(second.Id), third.Id)
+		checkNewReleaseId(t, "/instances/"+url.PathEscape(secondInstance.Id), third.Id)
+	})
+}
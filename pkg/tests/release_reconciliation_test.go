@@ -0,0 +1,213 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/camunda"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+	"github.com/google/uuid"
+)
+
+func TestReleaseReconciliation(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	t.Setenv("RELEASE_RECONCILIATION_REMOVE_ORPHANS", "true")
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, config, _, err := apiTestEnv(ctx, wg, true, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	camundaClient := camunda.New(config)
+
+	design, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+		Name:    "design",
+		BpmnXml: resources.NamedDescBpmn,
+		SvgXml:  resources.NamedDescSvg,
+	})
+	if !ok {
+		return
+	}
+	release, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+		DesignId: design.Id,
+		Name:     "release",
+	})
+	if !ok {
+		return
+	}
+	time.Sleep(time.Second)
+
+	checkDeployed := func(t *testing.T, id string, expected bool) {
+		t.Helper()
+		deployed, err := camundaClient.IsReleaseDeployed(id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if deployed != expected {
+			t.Error(id, deployed, expected)
+		}
+	}
+
+	t.Run("missing admin rights", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPost, userToken, apiUrl+"/release-reconciliation", nil, http.StatusForbidden)
+		testRequestStatus(t, http.MethodGet, userToken, apiUrl+"/release-reconciliation", nil, http.StatusForbidden)
+	})
+
+	t.Run("no report", func(t *testing.T) {
+		testRequestStatus(t, http.MethodGet, adminToken, apiUrl+"/release-reconciliation", nil, http.StatusNotFound)
+	})
+
+	t.Run("nothing to do", func(t *testing.T) {
+		report, ok := request[model.ReleaseReconciliationReport](t, http.MethodPost, adminToken, apiUrl+"/release-reconciliation?dry_run=true", nil)
+		if ok && (report.CheckedReleases != 1 || len(report.Redeployed) != 0 || len(report.RemovedDeployments) != 0 || len(report.Errors) != 0) {
+			t.Errorf("%#v", report)
+		}
+	})
+
+	t.Run("redeploy missing release", func(t *testing.T) {
+		err = camundaClient.RemoveRelease(release.Id)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		checkDeployed(t, release.Id, false)
+
+		report, ok := request[model.ReleaseReconciliationReport](t, http.MethodPost, adminToken, apiUrl+"/release-reconciliation?dry_run=true", nil)
+		if ok && (!report.DryRun || !slices.Equal(report.Redeployed, []string{release.Id})) {
+			t.Errorf("%#v", report)
+		}
+		checkDeployed(t, release.Id, false)
+
+		report, ok = request[model.ReleaseReconciliationReport](t, http.MethodPost, adminToken, apiUrl+"/release-reconciliation", nil)
+		if ok && (report.DryRun || !slices.Equal(report.Redeployed, []string{release.Id}) || len(report.Errors) != 0) {
+			t.Errorf("%#v", report)
+		}
+		checkDeployed(t, release.Id, true)
+	})
+
+	orphanId := uuid.NewString()
+	t.Run("remove orphaned deployment", func(t *testing.T) {
+		extended, ok := request[model.SmartServiceReleaseExtended](t, http.MethodGet, userToken, apiUrl+"/extended-releases/"+url.PathEscape(release.Id), nil)
+		if !ok {
+			return
+		}
+		extended.Id = orphanId
+		err, _ = camundaClient.DeployRelease(userId, extended)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		checkDeployed(t, orphanId, true)
+
+		report, ok := request[model.ReleaseReconciliationReport](t, http.MethodPost, adminToken, apiUrl+"/release-reconciliation", nil)
+		if ok && (len(report.Redeployed) != 0 || !slices.Equal(report.OrphanedDeployments, []string{camundaClient.ReleaseIdToKey(orphanId)}) || !slices.Equal(report.RemovedDeployments, []string{camundaClient.ReleaseIdToKey(orphanId)}) || len(report.Errors) != 0) {
+			t.Errorf("%#v", report)
+		}
+		checkDeployed(t, orphanId, false)
+		checkDeployed(t, release.Id, true)
+	})
+
+	t.Run("last report", func(t *testing.T) {
+		report, ok := request[model.ReleaseReconciliationReport](t, http.MethodGet, adminToken, apiUrl+"/release-reconciliation", nil)
+		if ok && (report.DryRun || !slices.Equal(report.RemovedDeployments, []string{camundaClient.ReleaseIdToKey(orphanId)})) {
+			t.Errorf("%#v", report)
+		}
+	})
+}
+
+func TestReleaseReconciliationReportOnly(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, config, _, err := apiTestEnv(ctx, wg, true, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	camundaClient := camunda.New(config)
+
+	design, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+		Name:    "design",
+		BpmnXml: resources.NamedDescBpmn,
+		SvgXml:  resources.NamedDescSvg,
+	})
+	if !ok {
+		return
+	}
+	release, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+		DesignId: design.Id,
+		Name:     "release",
+	})
+	if !ok {
+		return
+	}
+	time.Sleep(time.Second)
+
+	extended, ok := request[model.SmartServiceReleaseExtended](t, http.MethodGet, userToken, apiUrl+"/extended-releases/"+url.PathEscape(release.Id), nil)
+	if !ok {
+		return
+	}
+	orphanId := uuid.NewString()
+	extended.Id = orphanId
+	err, _ = camundaClient.DeployRelease(userId, extended)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	report, ok := request[model.ReleaseReconciliationReport](t, http.MethodPost, adminToken, apiUrl+"/release-reconciliation", nil)
+	if ok && (!slices.Equal(report.OrphanedDeployments, []string{camundaClient.ReleaseIdToKey(orphanId)}) || len(report.RemovedDeployments) != 0 || len(report.Errors) != 0) {
+		t.Errorf("%#v", report)
+	}
+	deployed, err := camundaClient.IsReleaseDeployed(orphanId)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !deployed {
+		t.Error("orphaned deployment should only be reported")
+	}
+}
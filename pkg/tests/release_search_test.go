@@ -0,0 +1,126 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestReleaseCapabilitySearch(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, err := apiTestEnv(ctx, wg, false, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	createRelease := func(name string, bpmn string, svg string) (model.SmartServiceRelease, bool) {
+		design, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+			Name:    name,
+			BpmnXml: bpmn,
+			SvgXml:  svg,
+		})
+		if !ok {
+			return model.SmartServiceRelease{}, false
+		}
+		return request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+			DesignId: design.Id,
+			Name:     name,
+		})
+	}
+
+	params, ok := createRelease("params", resources.ParamsBpmn, resources.ParamsSvg)
+	if !ok {
+		return
+	}
+	characteristic, ok := createRelease("characteristic", resources.JsonLocationInputBpmn, resources.ProcessDeploymentSvg)
+	if !ok {
+		return
+	}
+	_, ok = createRelease("no params", resources.NamedDescBpmn, resources.NamedDescSvg)
+	if !ok {
+		return
+	}
+	time.Sleep(time.Second)
+
+	check := func(query string, expectedIds ...string) {
+		t.Run(query, func(t *testing.T) {
+			releases, ok := request[[]model.SmartServiceRelease](t, http.MethodGet, userToken, apiUrl+"/releases?"+query, nil)
+			if !ok {
+				return
+			}
+			actualIds := []string{}
+			for _, release := range releases {
+				actualIds = append(actualIds, release.Id)
+			}
+			sort.Strings(actualIds)
+			sort.Strings(expectedIds)
+			if len(actualIds) != len(expectedIds) {
+				t.Error(actualIds, expectedIds)
+				return
+			}
+			for i := range actualIds {
+				if actualIds[i] != expectedIds[i] {
+					t.Error(actualIds, expectedIds)
+					return
+				}
+			}
+		})
+	}
+
+	check("function_id=foo", params.Id)
+	check("function_id=bar")
+	check("aspect_id=foo", params.Id)
+	check("device_class_id=foo", params.Id)
+	check("iot_type=import", params.Id)
+	check("function_id=foo&iot_type=import", params.Id)
+	check("aspect_id=foo&iot_type=device", params.Id)
+	check("aspect_id=foo&iot_type=group") //aspect and iot type of different parameters
+	check("characteristic_id=urn:infai:ses:characteristic:0b041ea3-8efd-4ce4-8130-d8af320326a4", characteristic.Id)
+	check("has_analytics=true")
+
+	t.Run("extended", func(t *testing.T) {
+		releases, ok := request[[]model.SmartServiceReleaseExtended](t, http.MethodGet, userToken, apiUrl+"/extended-releases?device_class_id=foo", nil)
+		if ok && (len(releases) != 1 || releases[0].Id != params.Id) {
+			t.Errorf("%#v", releases)
+		}
+	})
+
+	t.Run("unknown iot type", func(t *testing.T) {
+		testRequestStatus(t, http.MethodGet, userToken, apiUrl+"/releases?iot_type=foo", nil, http.StatusBadRequest)
+	})
+}
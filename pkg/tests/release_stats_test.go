@@ -0,0 +1,150 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"runtime/debug"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestReleaseStats(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, err := apiTestEnv(ctx, wg, true, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	design, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+		Name:    "design",
+		BpmnXml: resources.NamedDescBpmn,
+		SvgXml:  resources.NamedDescSvg,
+	})
+	if !ok {
+		return
+	}
+	first, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+		DesignId: design.Id,
+		Name:     "first",
+	})
+	if !ok {
+		return
+	}
+	time.Sleep(time.Second)
+	_, ok = request[model.SmartServiceInstance](t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(first.Id)+"/instances", model.SmartServiceInstanceInit{
+		SmartServiceInstanceInfo: model.SmartServiceInstanceInfo{Name: "instance"},
+		Parameters:               []model.SmartServiceParameter{},
+	})
+	if !ok {
+		return
+	}
+	time.Sleep(time.Second)
+	second, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+		DesignId: design.Id,
+		Name:     "second",
+	})
+	if !ok {
+		return
+	}
+	time.Sleep(time.Second)
+
+	t.Run("stats", func(t *testing.T) {
+		stats, ok := request[model.SmartServiceReleaseStats](t, http.MethodGet, userToken, apiUrl+"/releases/"+url.PathEscape(second.Id)+"/stats", nil)
+		if !ok {
+			return
+		}
+		if stats.ReleaseId != second.Id || stats.Instances != 0 {
+			t.Errorf("%#v", stats)
+		}
+		if len(stats.PreviousReleases) != 1 {
+			t.Errorf("%#v", stats.PreviousReleases)
+			return
+		}
+		if stats.PreviousReleases[0].ReleaseId != first.Id || stats.PreviousReleases[0].Instances != 1 || stats.PreviousReleases[0].Outdated != 1 {
+			t.Errorf("%#v", stats.PreviousReleases[0])
+		}
+	})
+
+	t.Run("admin stats", func(t *testing.T) {
+		stats, ok := request[model.SmartServiceReleaseStats](t, http.MethodGet, adminToken, apiUrl+"/releases/"+url.PathEscape(second.Id)+"/stats", nil)
+		if !ok {
+			return
+		}
+		if stats.Instances != 0 || len(stats.PreviousReleases) != 1 || stats.PreviousReleases[0].Instances != 1 || stats.PreviousReleases[0].Outdated != 1 {
+			t.Errorf("%#v", stats)
+		}
+	})
+
+	t.Run("list with stats", func(t *testing.T) {
+		releases, ok := request[[]model.SmartServiceRelease](t, http.MethodGet, userToken, apiUrl+"/releases?with_stats=true", nil)
+		if !ok {
+			return
+		}
+		if len(releases) != 2 {
+			t.Errorf("%#v", releases)
+			return
+		}
+		for _, release := range releases {
+			if release.Stats == nil {
+				t.Errorf("missing stats in %#v", release)
+				continue
+			}
+			expected := int64(0)
+			if release.Id == first.Id {
+				expected = 1
+			}
+			if release.Stats.Instances != expected {
+				t.Errorf("%#v", release.Stats)
+			}
+		}
+	})
+
+	t.Run("list without stats", func(t *testing.T) {
+		releases, ok := request[[]model.SmartServiceRelease](t, http.MethodGet, userToken, apiUrl+"/releases", nil)
+		if !ok {
+			return
+		}
+		for _, release := range releases {
+			if release.Stats != nil {
+				t.Errorf("unexpected stats in %#v", release)
+			}
+		}
+	})
+
+	t.Run("missing rights", func(t *testing.T) {
+		testRequestStatus(t, http.MethodGet, secondUserToken, apiUrl+"/releases/"+url.PathEscape(second.Id)+"/stats", nil, http.StatusForbidden)
+	})
+}
@@ -0,0 +1,179 @@
+/*
+ * Copyright 2026 InfAI (CC SES)
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"runtime/debug"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/model"
+	"github.com/SENERGY-Platform/smart-service-repository/pkg/tests/resources"
+)
+
+func TestTestInstances(t *testing.T) {
+	if CI {
+		t.Skip("not in ci")
+	}
+	t.Setenv("TEST_INSTANCE_TTL", "1m")
+
+	wg := &sync.WaitGroup{}
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	apiUrl, _, _, _, ctrl, err := apiTestEnvWithController(ctx, wg, true, nil, func(err error) {
+		debug.PrintStack()
+		t.Error(err)
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	design, ok := request[model.SmartServiceDesign](t, http.MethodPost, userToken, apiUrl+"/designs", model.SmartServiceDesign{
+		BpmnXml: resources.ProcessDeploymentBpmn,
+		SvgXml:  resources.ProcessDeploymentSvg,
+	})
+	if !ok {
+		return
+	}
+	release, ok := request[model.SmartServiceRelease](t, http.MethodPost, userToken, apiUrl+"/releases", model.SmartServiceRelease{
+		DesignId: design.Id,
+		Name:     "release name",
+	})
+	if !ok {
+		return
+	}
+
+	time.Sleep(5 * time.Second) //allow async cqrs
+
+	parameters, ok := request[[]model.SmartServiceExtendedParameter](t, http.MethodGet, userToken, apiUrl+"/releases/"+url.PathEscape(release.Id)+"/parameters", nil)
+	if !ok {
+		return
+	}
+	instanceInit := func(name string) model.SmartServiceInstanceInit {
+		return model.SmartServiceInstanceInit{
+			SmartServiceInstanceInfo: model.SmartServiceInstanceInfo{Name: name},
+			Parameters:               fillTestParameter(parameters),
+		}
+	}
+
+	normal, ok := request[model.SmartServiceInstance](t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(release.Id)+"/instances", instanceInit("normal"))
+	if !ok {
+		return
+	}
+
+	t.Run("ttl exceeds config", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(release.Id)+"/test-instances?ttl=2h", instanceInit("test"), http.StatusBadRequest)
+	})
+
+	t.Run("invalid ttl", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(release.Id)+"/test-instances?ttl=foo", instanceInit("test"), http.StatusBadRequest)
+	})
+
+	t.Run("missing release access", func(t *testing.T) {
+		testRequestStatus(t, http.MethodPost, secondUserToken, apiUrl+"/releases/"+url.PathEscape(release.Id)+"/test-instances", instanceInit("test"), http.StatusForbidden)
+	})
+
+	longLiving, ok := request[model.SmartServiceInstance](t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(release.Id)+"/test-instances", instanceInit("long living test"))
+	if !ok {
+		return
+	}
+	shortLiving, ok := request[model.SmartServiceInstance](t, http.MethodPost, userToken, apiUrl+"/releases/"+url.PathEscape(release.Id)+"/test-instances?ttl=1s", instanceInit("short living test"))
+	if !ok {
+		return
+	}
+
+	t.Run("test flags", func(t *testing.T) {
+		if !longLiving.Test || !shortLiving.Test || normal.Test {
+			t.Error(longLiving.Test, shortLiving.Test, normal.Test)
+		}
+		if normal.ExpiresAt != 0 {
+			t.Error(normal.ExpiresAt)
+		}
+		if longLiving.ExpiresAt < time.Now().Add(50*time.Second).Unix() || longLiving.ExpiresAt > time.Now().Add(time.Minute).Unix() {
+			t.Error(longLiving.ExpiresAt)
+		}
+		if shortLiving.ExpiresAt > time.Now().Add(time.Second).Unix() {
+			t.Error(shortLiving.ExpiresAt)
+		}
+	})
+
+	checkList := func(query string, expectedIds ...string) {
+		t.Run("list "+query, func(t *testing.T) {
+			instances, ok := request[[]model.SmartServiceInstance](t, http.MethodGet, userToken, apiUrl+"/instances?"+query, nil)
+			if !ok {
+				return
+			}
+			actualIds := map[string]bool{}
+			for _, instance := range instances {
+				actualIds[instance.Id] = true
+			}
+			if len(actualIds) != len(expectedIds) {
+				t.Error(actualIds, expectedIds)
+				return
+			}
+			for _, id := range expectedIds {
+				if !actualIds[id] {
+					t.Error(actualIds, expectedIds)
+					return
+				}
+			}
+		})
+	}
+
+	checkList("", normal.Id)
+	checkList("test=false", normal.Id)
+	checkList("test=true", longLiving.Id, shortLiving.Id)
+
+	t.Run("get test instance", func(t *testing.T) {
+		instance, ok := request[model.SmartServiceInstance](t, http.MethodGet, userToken, apiUrl+"/instances/"+url.PathEscape(shortLiving.Id), nil)
+		if ok && (!instance.Test || instance.ExpiresAt != shortLiving.ExpiresAt) {
+			t.Errorf("%#v", instance)
+		}
+	})
+
+	time.Sleep(2 * time.Second)
+
+	t.Run("cleanup", func(t *testing.T) {
+		errs := ctrl.Cleanup(true)
+		if len(errs) > 0 {
+			t.Error(errs)
+		}
+	})
+
+	checkList("test=true", longLiving.Id)
+	checkList("", normal.Id)
+
+	t.Run("expired instance removed", func(t *testing.T) {
+		resp, err := get(userToken, apiUrl+"/instances/"+url.PathEscape(shortLiving.Id))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			t.Error(resp.StatusCode)
+		}
+	})
+}